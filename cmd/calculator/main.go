@@ -2,12 +2,19 @@ package main
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/radoslavboychev/price-calculator-kata/config"
 	"github.com/radoslavboychev/price-calculator-kata/internal/cap"
 	"github.com/radoslavboychev/price-calculator-kata/internal/combining"
 	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/exemption"
+	"github.com/radoslavboychev/price-calculator-kata/internal/jurisdiction"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+	"github.com/radoslavboychev/price-calculator-kata/internal/vat"
 	"github.com/radoslavboychev/price-calculator-kata/pkg/calculator"
 )
 
@@ -18,52 +25,257 @@ func main() {
 	logConfig()
 
 	// CURRENCY
-	defaultCurrency := currency.LoadCurrency()
+	defaultCurrency, err := currency.LoadCurrency()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// TAX
-	tax := *models.NewTax(conf.Tax)
+	taxRate := parsePercentage("TAX_RATE", conf.Tax)
+	tax := *models.NewTax(taxRate)
+
+	taxRates, err := models.ParseTaxRates(conf.TaxRates)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defaultCategory := models.TaxCategory(conf.TaxDefaultCategory)
+	if _, ok := taxRates[defaultCategory]; !ok {
+		taxRates[defaultCategory] = taxRate
+	}
+	categoryRates, err := models.NewTaxRates(defaultCategory, taxRates)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// STORE TIME ZONE
+	storeLocation, err := time.LoadLocation(conf.StoreTimeZone)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// DISCOUNT
-	universalDiscount := models.NewUniversalDiscount(conf.UniversalDiscountRate, models.NewMoney(defaultCurrency.Code, 0))
-	specialDiscount := models.NewSpecialDiscount(conf.SpecialDiscountUPC, conf.SpecialDiscountRate, models.NewMoney(defaultCurrency.Code, 0))
+	universalDiscount := models.NewUniversalDiscount(parsePercentage("UNIVERSAL_DISCOUNT_RATE", conf.UniversalDiscountRate), models.NewMoney(defaultCurrency.Code, "0"))
+	specialDiscount := models.NewSpecialDiscount(conf.SpecialDiscountUPC, parsePercentage("SPECIAL_DISCOUNT_RATE", conf.SpecialDiscountRate), models.NewMoney(defaultCurrency.Code, "0"))
+	if conf.SpecialDiscountTarget != "" {
+		target, err := models.ParseMatcher(conf.SpecialDiscountTarget)
+		if err != nil {
+			log.Fatal(err)
+		}
+		specialDiscount.WithMatcher(target)
+	}
+	universalDiscount.WithBeforeTax(conf.UniversalBeforeTax)
+	specialDiscount.WithBeforeTax(conf.SpecialBeforeTax)
 	discount := *models.NewDiscount(*universalDiscount, *specialDiscount, models.TakesPrecedence(conf.DiscountTakesPrecedence))
+	if conf.DiscountsFile != "" {
+		rules, err := models.LoadDiscountRules(conf.DiscountsFile, storeLocation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		discount = *models.NewDiscounts(rules...)
+	}
 
 	// EXPENSE
-	expenseAbsolute := models.NewExpenseAbsolute("Transport", conf.CostAbsolute)
-	expensePercentage := models.NewExpensePercentage("Packaging", conf.CostPercentage)
+	expenseAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(defaultCurrency.Code, conf.CostAbsolute)).
+		WithTaxable(conf.CostAbsoluteTaxable).
+		WithDiscountable(conf.CostAbsoluteDiscount)
+	expensePercentage := models.NewExpensePercentage("Packaging", parsePercentage("COST_PERCENTAGE", conf.CostPercentage)).
+		WithTaxable(conf.CostPercentageTaxable).
+		WithDiscountable(conf.CostPercentageDiscount)
 	productCosts := models.NewCosts(expenseAbsolute, expensePercentage)
 
 	// COMBINING
 	combineType := combining.NewCombineTypeFromConfig()
 
 	// CAP
-	discountCap := cap.NewDiscountCap(conf.CapValue)
+	discountCap := cap.NewDiscountCap(conf.CapValue, defaultCurrency.Code)
+
+	// ROUNDING
+	rounding := models.NewRounding(
+		format.RoundingMode(conf.RoundingTax),
+		format.RoundingMode(conf.RoundingDiscount),
+		format.RoundingMode(conf.RoundingExpense),
+		format.RoundingMode(conf.RoundingTotal),
+	)
 
 	// create an object
-	p := models.NewProduct("The Little Prince", 123456, models.NewMoney(defaultCurrency.Code, 20.25), productCosts)
+	p := models.NewProduct("The Little Prince", 123456, models.NewMoney(defaultCurrency.Code, "20.25"), productCosts).
+		WithTaxCategory(models.TaxCategory(conf.ProductTaxCategory)).
+		WithTags(strings.Fields(conf.ProductTags)...)
+	if conf.ProductTaxExemption != "" {
+		p = p.WithTaxExemption(conf.ProductTaxExemption)
+	}
 
 	// create the calculator object
-	calc := calculator.NewCalculator(tax, discount, combineType, discountCap)
+	calc := calculator.NewCalculator(tax, discount, combineType, discountCap).WithRounding(rounding).
+		WithTaxRates(categoryRates).
+		WithPricingMode(models.NewPricingMode(conf.PricingMode))
+
+	// TAX SCHEDULE
+	if conf.TaxScheduleFile != "" {
+		schedule, err := models.LoadTaxSchedule(conf.TaxScheduleFile, defaultCategory, storeLocation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calc.WithTaxSchedule(schedule)
+	}
+
+	// TRANSACTION DATE
+	if conf.TransactionDate != "" {
+		transactionDate, err := time.ParseInLocation("2006-01-02 15:04", conf.TransactionDate, storeLocation)
+		if err != nil {
+			transactionDate, err = time.ParseInLocation("2006-01-02", conf.TransactionDate, storeLocation)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		calc.WithTransactionTime(transactionDate)
+	}
+
+	// JURISDICTION
+	if conf.Destination != "" {
+		resolver, err := jurisdiction.LoadResolver(conf.JurisdictionFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calc.WithJurisdiction(resolver, conf.Destination)
+	}
+
+	// VAT
+	if conf.VATSellerCountry != "" {
+		calc.WithVAT(vat.NewStandardRules(), vat.Sale{
+			SellerCountry: conf.VATSellerCountry,
+			BuyerCountry:  conf.VATBuyerCountry,
+			BuyerVATID:    conf.VATBuyerID,
+		})
+	}
+
+	// EXEMPTION
+	if conf.ExemptionCertificate != "" {
+		var expires time.Time
+		if conf.ExemptionExpires != "" {
+			expires, err = time.Parse("2006-01-02", conf.ExemptionExpires)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		var scope []models.TaxCategory
+		for _, category := range strings.Split(conf.ExemptionScope, ",") {
+			if strings.TrimSpace(category) != "" {
+				scope = append(scope, models.TaxCategory(strings.TrimSpace(category)))
+			}
+		}
+
+		calc.WithExemptions(exemption.NewPartialCertificate(
+			conf.ExemptionCertificate,
+			conf.ExemptionReason,
+			parsePercentage("EXEMPTION_PORTION", conf.ExemptionPortion),
+			expires,
+			scope...,
+		))
+	}
+
+	// CASH ROUNDING
+	switch strings.ToUpper(conf.CashRounding) {
+	case "":
+	case "CURRENCY":
+		calc.WithCashRounding(models.NewCurrencyCashRounding(format.RoundingMode(conf.CashRoundingMode)))
+	default:
+		calc.WithCashRounding(models.NewCashRounding(conf.CashRounding, format.RoundingMode(conf.CashRoundingMode)))
+	}
+
+	// CONVERSION
+	if conf.DisplayCurrency != "" {
+		displayCurrency, err := currency.Lookup(conf.DisplayCurrency)
+		if err != nil {
+			log.Fatal(err)
+		}
+		base, err := currency.Lookup(conf.RatesBase)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rates, err := currency.LoadRates(conf.RatesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calc.WithConversion(currency.NewConverter(base.Code, rates...), displayCurrency.Code)
+
+		if conf.RatesAsOf != "" {
+			asOf, err := time.Parse("2006-01-02", conf.RatesAsOf)
+			if err != nil {
+				log.Fatal(err)
+			}
+			calc.WithAsOf(asOf)
+		}
+	}
 
 	// conduct all calculations for the specific product
-	res := calc.Calculate(&p)
+	res, err := calc.Calculate(&p)
+	if err != nil {
+		log.Fatal(err)
+	}
 	res.Report()
 }
 
+// parsePercentage parses a percentage from the config, stops the application if it is invalid or not between 0 and 100
+func parsePercentage(key, value string) utils.Percentage {
+	p, err := utils.ParsePercentage(value)
+	if err != nil {
+		log.Fatalf("%v: %v", key, err)
+	}
+	return p
+}
+
 // logConfig prints the currently loaded config
 func logConfig() {
 	conf := config.LoadConfig()
 
 	log.Println("CONFIGURATION")
 	log.Println("###########")
-	log.Printf("Tax Rate: %v\n", conf.Tax)
-	log.Printf("Universal Discount Rate: %v \n", conf.UniversalDiscountRate)
-	log.Printf("Special Discount: Rate - %v%%; UPC - %v \n", conf.SpecialDiscountRate, conf.SpecialDiscountUPC)
+	log.Printf("Tax Rate: %v%%\n", conf.Tax)
+	if conf.TaxRates != "" {
+		log.Printf("Tax Rates: %v (default category - %v)\n", conf.TaxRates, conf.TaxDefaultCategory)
+	}
+	if conf.TaxScheduleFile != "" {
+		log.Printf("Tax schedule: %v (default category - %v)\n", conf.TaxScheduleFile, conf.TaxDefaultCategory)
+	}
+	if conf.TransactionDate != "" {
+		log.Printf("Transaction date: %v\n", conf.TransactionDate)
+	}
+	if conf.Destination != "" {
+		log.Printf("Destination: %v (jurisdiction rates from %v)\n", conf.Destination, conf.JurisdictionFile)
+	}
+	if conf.VATSellerCountry != "" {
+		log.Printf("VAT: Seller - %v; Buyer - %v; Buyer VAT ID - %v\n", conf.VATSellerCountry, conf.VATBuyerCountry, conf.VATBuyerID)
+	}
+	if conf.ProductTaxExemption != "" {
+		log.Printf("Product is exempt from tax: %v\n", conf.ProductTaxExemption)
+	}
+	if conf.ExemptionCertificate != "" {
+		log.Printf("Exemption certificate: %v (%v); Scope - %v; Portion - %v%%; Expires - %v\n",
+			conf.ExemptionCertificate, conf.ExemptionReason, conf.ExemptionScope, conf.ExemptionPortion, conf.ExemptionExpires)
+	}
+	if models.NewPricingMode(conf.PricingMode) == models.PricingGross {
+		log.Println("Prices include tax!")
+	}
+	if conf.DiscountsFile != "" {
+		log.Printf("Discounts: %v (store time zone - %v)\n", conf.DiscountsFile, conf.StoreTimeZone)
+	}
+	log.Printf("Universal Discount Rate: %v%% \n", conf.UniversalDiscountRate)
+	if conf.SpecialDiscountTarget != "" {
+		log.Printf("Special Discount: Rate - %v%%; Target - %v \n", conf.SpecialDiscountRate, conf.SpecialDiscountTarget)
+	} else {
+		log.Printf("Special Discount: Rate - %v%%; UPC - %v \n", conf.SpecialDiscountRate, conf.SpecialDiscountUPC)
+	}
 
-	switch conf.DiscountTakesPrecedence {
-	case 1:
+	universalBeforeTax := conf.UniversalBeforeTax || conf.DiscountTakesPrecedence == 1
+	specialBeforeTax := conf.SpecialBeforeTax || conf.DiscountTakesPrecedence == 2
+	switch {
+	case universalBeforeTax && specialBeforeTax:
+		log.Println("Universal and special discount take precedence over tax!")
+	case universalBeforeTax:
 		log.Println("Universal discount takes precedence over tax!")
-	case 2:
+	case specialBeforeTax:
 		log.Println("Special discount takes precedence over tax!")
 	default:
 		log.Println("Discount does not take precedence over tax!")
@@ -78,7 +290,22 @@ func logConfig() {
 		log.Println("No discount cap has been set!")
 	}
 
-	log.Printf("Currency: %v\n", currency.LoadCurrency().Code)
+	if c, err := currency.LoadCurrency(); err == nil {
+		log.Printf("Currency: %v (%v)\n", c.Alpha, c.Name)
+	} else {
+		log.Printf("Invalid currency: %v\n", err)
+	}
+
+	if conf.DisplayCurrency != "" {
+		log.Printf("Display currency: %v (rates from %v)\n", conf.DisplayCurrency, conf.RatesFile)
+		if conf.RatesAsOf != "" {
+			log.Printf("Exchange rates as of: %v\n", conf.RatesAsOf)
+		}
+	}
+
+	log.Printf("Expenses: Packaging - %v%% (taxable - %v; discountable - %v); Transport - %v (taxable - %v; discountable - %v)\n",
+		conf.CostPercentage, conf.CostPercentageTaxable, conf.CostPercentageDiscount,
+		conf.CostAbsolute, conf.CostAbsoluteTaxable, conf.CostAbsoluteDiscount)
 
 	switch conf.CombinationType {
 	case 0:
@@ -89,6 +316,17 @@ func logConfig() {
 		log.Printf("Invalid combination type")
 	}
 
+	log.Printf("Rounding: Tax - %v; Discount - %v; Expense - %v; Total - %v\n",
+		format.RoundingMode(conf.RoundingTax),
+		format.RoundingMode(conf.RoundingDiscount),
+		format.RoundingMode(conf.RoundingExpense),
+		format.RoundingMode(conf.RoundingTotal),
+	)
+
+	if conf.CashRounding != "" {
+		log.Printf("Cash rounding: %v, %v\n", conf.CashRounding, format.RoundingMode(conf.CashRoundingMode))
+	}
+
 	log.Println("Executing calculations...")
 	log.Println("###########")
 }
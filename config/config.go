@@ -8,17 +8,54 @@ import (
 
 // config struct contains all configurable variables for the calculator application
 type config struct {
-	Tax                     uint16  `mapstructure:"TAX_RATE"`
-	UniversalDiscountRate   uint16  `mapstructure:"UNIVERSAL_DISCOUNT_RATE"`
-	SpecialDiscountRate     uint16  `mapstructure:"SPECIAL_DISCOUNT_RATE"`
-	SpecialDiscountUPC      int     `mapstructure:"SPECIAL_DISCOUNT_UPC"`
-	DiscountTakesPrecedence uint16  `mapstructure:"DISCOUNT_TAKES_PRECEDENCE"`
-	CapType                 uint16  `mapstructure:"DISCOUNT_CAP_TYPE"`
-	CapValue                float64 `mapstructure:"CAP_VALUE"`
-	Currency                uint16  `mapstructure:"CURRENCY"`
-	CombinationType         uint16  `mapstructure:"COMBINE_TYPE"`
-	CostPercentage          float64 `mapstructure:"COST_PERCENTAGE"`
-	CostAbsolute            float64 `mapstructure:"COST_ABSOLUTE"`
+	Tax                     string `mapstructure:"TAX_RATE"`
+	TaxRates                string `mapstructure:"TAX_RATES"`
+	TaxDefaultCategory      string `mapstructure:"TAX_DEFAULT_CATEGORY"`
+	ProductTaxCategory      string `mapstructure:"PRODUCT_TAX_CATEGORY"`
+	TaxScheduleFile         string `mapstructure:"TAX_SCHEDULE_FILE"`
+	TransactionDate         string `mapstructure:"TRANSACTION_DATE"`
+	PricingMode             uint16 `mapstructure:"PRICING_MODE"`
+	JurisdictionFile        string `mapstructure:"JURISDICTION_FILE"`
+	Destination             string `mapstructure:"DESTINATION"`
+	VATSellerCountry        string `mapstructure:"VAT_SELLER_COUNTRY"`
+	VATBuyerCountry         string `mapstructure:"VAT_BUYER_COUNTRY"`
+	VATBuyerID              string `mapstructure:"VAT_BUYER_ID"`
+	ProductTaxExemption     string `mapstructure:"PRODUCT_TAX_EXEMPTION"`
+	ExemptionCertificate    string `mapstructure:"EXEMPTION_CERTIFICATE"`
+	ExemptionReason         string `mapstructure:"EXEMPTION_REASON"`
+	ExemptionScope          string `mapstructure:"EXEMPTION_SCOPE"`
+	ExemptionPortion        string `mapstructure:"EXEMPTION_PORTION"`
+	ExemptionExpires        string `mapstructure:"EXEMPTION_EXPIRES"`
+	UniversalDiscountRate   string `mapstructure:"UNIVERSAL_DISCOUNT_RATE"`
+	SpecialDiscountRate     string `mapstructure:"SPECIAL_DISCOUNT_RATE"`
+	SpecialDiscountUPC      int    `mapstructure:"SPECIAL_DISCOUNT_UPC"`
+	SpecialDiscountTarget   string `mapstructure:"SPECIAL_DISCOUNT_TARGET"`
+	ProductTags             string `mapstructure:"PRODUCT_TAGS"`
+	DiscountTakesPrecedence uint16 `mapstructure:"DISCOUNT_TAKES_PRECEDENCE"`
+	UniversalBeforeTax      bool   `mapstructure:"UNIVERSAL_DISCOUNT_BEFORE_TAX"`
+	SpecialBeforeTax        bool   `mapstructure:"SPECIAL_DISCOUNT_BEFORE_TAX"`
+	DiscountsFile           string `mapstructure:"DISCOUNTS_FILE"`
+	StoreTimeZone           string `mapstructure:"STORE_TIME_ZONE"`
+	CapType                 uint16 `mapstructure:"DISCOUNT_CAP_TYPE"`
+	CapValue                string `mapstructure:"CAP_VALUE"`
+	Currency                string `mapstructure:"CURRENCY"`
+	CombinationType         uint16 `mapstructure:"COMBINE_TYPE"`
+	CostPercentage          string `mapstructure:"COST_PERCENTAGE"`
+	CostAbsolute            string `mapstructure:"COST_ABSOLUTE"`
+	CostPercentageTaxable   bool   `mapstructure:"COST_PERCENTAGE_TAXABLE"`
+	CostPercentageDiscount  bool   `mapstructure:"COST_PERCENTAGE_DISCOUNTABLE"`
+	CostAbsoluteTaxable     bool   `mapstructure:"COST_ABSOLUTE_TAXABLE"`
+	CostAbsoluteDiscount    bool   `mapstructure:"COST_ABSOLUTE_DISCOUNTABLE"`
+	RoundingTax             uint16 `mapstructure:"ROUNDING_TAX"`
+	RoundingDiscount        uint16 `mapstructure:"ROUNDING_DISCOUNT"`
+	RoundingExpense         uint16 `mapstructure:"ROUNDING_EXPENSE"`
+	RoundingTotal           uint16 `mapstructure:"ROUNDING_TOTAL"`
+	DisplayCurrency         string `mapstructure:"DISPLAY_CURRENCY"`
+	RatesBase               string `mapstructure:"RATES_BASE"`
+	RatesFile               string `mapstructure:"RATES_FILE"`
+	CashRounding            string `mapstructure:"CASH_ROUNDING"`
+	CashRoundingMode        uint16 `mapstructure:"CASH_ROUNDING_MODE"`
+	RatesAsOf               string `mapstructure:"RATES_AS_OF"`
 }
 
 // variable to unmarshal the config in
@@ -50,15 +87,52 @@ func LoadConfig() config {
 
 // setDefaultConfigValues defines some default values in the configuration in case they've not been set
 func setDefaultConfigValues() {
-	viper.SetDefault("TAX_RATE", 20)
-	viper.SetDefault("UNIVERSAL_DISCOUNT_RATE", 0)
+	viper.SetDefault("TAX_RATE", "20")
+	viper.SetDefault("TAX_RATES", "")
+	viper.SetDefault("TAX_DEFAULT_CATEGORY", "standard")
+	viper.SetDefault("PRODUCT_TAX_CATEGORY", "")
+	viper.SetDefault("TAX_SCHEDULE_FILE", "")
+	viper.SetDefault("TRANSACTION_DATE", "")
+	viper.SetDefault("PRICING_MODE", 0)
+	viper.SetDefault("JURISDICTION_FILE", "../.././config/jurisdictions.csv")
+	viper.SetDefault("DESTINATION", "")
+	viper.SetDefault("VAT_SELLER_COUNTRY", "")
+	viper.SetDefault("VAT_BUYER_COUNTRY", "")
+	viper.SetDefault("VAT_BUYER_ID", "")
+	viper.SetDefault("PRODUCT_TAX_EXEMPTION", "")
+	viper.SetDefault("EXEMPTION_CERTIFICATE", "")
+	viper.SetDefault("EXEMPTION_REASON", "")
+	viper.SetDefault("EXEMPTION_SCOPE", "")
+	viper.SetDefault("EXEMPTION_PORTION", "100")
+	viper.SetDefault("EXEMPTION_EXPIRES", "")
+	viper.SetDefault("UNIVERSAL_DISCOUNT_RATE", "0")
 	viper.SetDefault("SPECIAL_DISCOUNT_UPC", 0)
-	viper.SetDefault("SPECIAL_DISCOUNT_RATE", 0)
+	viper.SetDefault("SPECIAL_DISCOUNT_TARGET", "")
+	viper.SetDefault("PRODUCT_TAGS", "")
+	viper.SetDefault("SPECIAL_DISCOUNT_RATE", "0")
 	viper.SetDefault("DISCOUNT_TAKES_PRECEDENCE", 0)
+	viper.SetDefault("UNIVERSAL_DISCOUNT_BEFORE_TAX", false)
+	viper.SetDefault("SPECIAL_DISCOUNT_BEFORE_TAX", false)
+	viper.SetDefault("DISCOUNTS_FILE", "")
+	viper.SetDefault("STORE_TIME_ZONE", "UTC")
 	viper.SetDefault("DISCOUNT_CAP_TYPE", 0)
-	viper.SetDefault("CAP_VALUE", 0)
-	viper.SetDefault("CURRENCY", 0)
+	viper.SetDefault("CAP_VALUE", "0")
+	viper.SetDefault("CURRENCY", "USD")
 	viper.SetDefault("COMBINE_TYPE", 0)
-	viper.SetDefault("COST_PERCENTAGE", 0)
-	viper.SetDefault("COST_PERCENTAGE", 0)
+	viper.SetDefault("COST_PERCENTAGE", "0")
+	viper.SetDefault("COST_ABSOLUTE", "0")
+	viper.SetDefault("COST_PERCENTAGE_TAXABLE", false)
+	viper.SetDefault("COST_PERCENTAGE_DISCOUNTABLE", false)
+	viper.SetDefault("COST_ABSOLUTE_TAXABLE", false)
+	viper.SetDefault("COST_ABSOLUTE_DISCOUNTABLE", false)
+	viper.SetDefault("ROUNDING_TAX", 0)
+	viper.SetDefault("ROUNDING_DISCOUNT", 0)
+	viper.SetDefault("ROUNDING_EXPENSE", 0)
+	viper.SetDefault("ROUNDING_TOTAL", 0)
+	viper.SetDefault("DISPLAY_CURRENCY", "")
+	viper.SetDefault("RATES_BASE", "EUR")
+	viper.SetDefault("RATES_FILE", "../.././config/rates.csv")
+	viper.SetDefault("RATES_AS_OF", "")
+	viper.SetDefault("CASH_ROUNDING", "")
+	viper.SetDefault("CASH_ROUNDING_MODE", 0)
 }
@@ -2,12 +2,14 @@ package cap
 
 import (
 	"github.com/radoslavboychev/price-calculator-kata/config"
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
 )
 
 // Cap interface defines behavior for all types which implement it
 type DiscountCap interface {
-	CalculateCap(startingPrice models.Money, discount float64) float64
+	CalculateCap(startingPrice models.Money, discount models.Money) (models.Money, error)
 }
 
 // CapAbsolute represents discount cap based on absolute value
@@ -17,91 +19,102 @@ type capAbsolute struct {
 
 // CapPercentage represents discount cap based on percentage values
 type capPercentage struct {
-	Value float64
+	Value utils.Percentage
 }
 
-// CalculateCap calculates the cap amount for absolute cap values
-func (c *capAbsolute) CalculateCap(startingPrice models.Money, discount float64) float64 {
-
-	if discount > c.Value.Value {
-		discount = c.Value.Value
-	}
-	return discount
+// CalculateCap calculates the cap amount for absolute cap values, returns an error if the cap is not in the currency of the discount
+func (c *capAbsolute) CalculateCap(startingPrice models.Money, discount models.Money) (models.Money, error) {
+	return capDiscount(discount, c.Value)
 }
 
 // CalculateCap calculates the cap amount for percentage-based cap values
-func (c *capPercentage) CalculateCap(startingPrice models.Money, discount float64) float64 {
+func (c *capPercentage) CalculateCap(startingPrice models.Money, discount models.Money) (models.Money, error) {
 	if c.Value == 0 {
-		c.Value = 100
+		c.Value = utils.HundredPercent
 	}
-	capAmount := (c.Value / 100) * startingPrice.Value
-	if discount > capAmount {
-		discount = capAmount
+	return capDiscount(discount, startingPrice.Percent(c.Value))
+}
+
+// capDiscount returns the discount, limited to the cap amount.
+// Discounts on credits are negative, so the size of the discount is capped regardless of its sign
+func capDiscount(discount, capAmount models.Money) (models.Money, error) {
+	limit := capAmount.Abs()
+
+	cmp, err := discount.Abs().Cmp(limit)
+	if err != nil {
+		return discount, err
+	}
+
+	if cmp > 0 {
+		if discount.Amount < 0 {
+			return limit.Neg(), nil
+		}
+		return limit, nil
 	}
-	return discount
+	return discount, nil
 }
 
 // newCapPercentage constructor function for percentage based discount caps
-func newCapPercentage(value float64) *capPercentage {
+func newCapPercentage(value utils.Percentage) *capPercentage {
 	// if cap is 0%, set it to 100% to basically remove it
 	if value == 0 {
-		value = 100
-	}
-
-	if value < 0 {
-		value = 100
+		value = utils.HundredPercent
 	}
 
 	return &capPercentage{
-		Value: value,
+		Value: value.Clamp(),
 	}
 }
 
 // newCapAbsolute constructor function for absolute value based discount caps
-func newCapAbsolute(value float64) *capAbsolute {
-	// if the cap is 0, it is not valid, set it very high to basically remove it
-	if value == 0 {
-		value = 1000000
-	}
+func newCapAbsolute(value models.Money) *capAbsolute {
+	amount := value
 
-	//  if the cap is a negative number, it is not valid, set it very high to basically remove it
-	if value < 0 {
-		value = 1000000
+	// if the cap is 0 or negative, it is not valid, set it very high to basically remove it
+	if amount.Amount <= 0 {
+		amount = models.NewMoney(value.Currency, "1000000")
 	}
 
 	return &capAbsolute{
-		Value: models.Money{
-			Value: value,
-		},
+		Value: amount,
+	}
+}
+
+// parseCapPercentage parses a percentage cap value, e.g. "12.5". Invalid values are set to 0 which removes the cap
+func parseCapPercentage(value string) utils.Percentage {
+	rate, err := utils.ParsePercentage(value)
+	if err != nil {
+		return 0
 	}
+	return rate
 }
 
 // NewDiscountCap checks the type of discount cap defined in the config (absolute or percentage) and returns a new instance of the cap
-// with the values from config
+// with the values from config. Absolute caps are set in the specified currency
 // if an invalid value for the cap is set, returns a new cap that is set to 100% of the product price,
 // meaning a cap would practically not exist
-func NewDiscountCap(value float64) DiscountCap {
+func NewDiscountCap(value string, code currency.CurrencyCode) DiscountCap {
 	conf := config.LoadConfig()
 
 	switch conf.CapType {
 	case 1:
-		return newCapPercentage(value)
+		return newCapPercentage(parseCapPercentage(value))
 	case 2:
-		return newCapAbsolute(value)
+		return newCapAbsolute(models.NewMoney(code, value))
 	default:
-		return newCapPercentage(100)
+		return newCapPercentage(utils.HundredPercent)
 	}
 }
 
 // NewDiscountCapTesting slightly different method of generating the cap for testing purposes.
-// Instead of reading
-func NewDiscountCapTesting(capType uint16, value float64) DiscountCap {
+// Instead of reading the cap type from the config it is passed in. Absolute caps are set in the specified currency
+func NewDiscountCapTesting(capType uint16, value string, code currency.CurrencyCode) DiscountCap {
 	switch capType {
 	case 1:
-		return newCapAbsolute(value)
+		return newCapAbsolute(models.NewMoney(code, value))
 	case 2:
-		return newCapPercentage(value)
+		return newCapPercentage(parseCapPercentage(value))
 	default:
-		return newCapPercentage(100)
+		return newCapPercentage(utils.HundredPercent)
 	}
 }
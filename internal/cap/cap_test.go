@@ -5,6 +5,7 @@ import (
 
 	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,18 +13,19 @@ func TestCalculateCap(t *testing.T) {
 	// Case for calculating the discount cap from absolute amount
 	t.Run("CALCULATE_CAP_ABSOLUTE_VALUE", func(t *testing.T) {
 		// Arrange
-		cap := newCapAbsolute(2)
+		cap := newCapAbsolute(models.NewMoney(currency.USD, "2"))
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
-		discount := models.NewDiscount(*models.NewUniversalDiscount(20, models.NewMoney(currency.USD, 5)),
-			*models.NewSpecialDiscount(p.UPC(), 0, models.NewMoney(currency.USD, 0)), models.NoPrecedence)
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+		discount := models.NewDiscount(*models.NewUniversalDiscount(utils.WholePercentage(20), models.NewMoney(currency.USD, "5")),
+			*models.NewSpecialDiscount(p.UPC(), utils.WholePercentage(0), models.NewMoney(currency.USD, "0")), models.NoPrecedence)
 
-		var expectedResult float64 = 2
+		expectedResult := models.NewMoney(currency.USD, "2")
 
 		// Act
-		res := cap.CalculateCap(p.Price(), discount.UniversalDiscount.Amount.Value)
+		res, err := cap.CalculateCap(p.Price(), discount.Rules[0].Amount)
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 
 	})
@@ -31,17 +33,18 @@ func TestCalculateCap(t *testing.T) {
 	// Case for calculating the discount cap from percentage
 	t.Run("CALCULATE_CAP_PERCENTAGE", func(t *testing.T) {
 		// Arrange
-		cap := newCapPercentage(10)
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
-		discount := models.NewDiscount(*models.NewUniversalDiscount(20, models.NewMoney(currency.USD, 5)),
-			*models.NewSpecialDiscount(p.UPC(), 0, models.NewMoney(currency.USD, 0)), models.NoPrecedence)
+		cap := newCapPercentage(utils.WholePercentage(10))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+		discount := models.NewDiscount(*models.NewUniversalDiscount(utils.WholePercentage(20), models.NewMoney(currency.USD, "5")),
+			*models.NewSpecialDiscount(p.UPC(), utils.WholePercentage(0), models.NewMoney(currency.USD, "0")), models.NoPrecedence)
 
-		var expectedResult float64 = 2.025
+		expectedResult := models.Money{Currency: currency.USD, Amount: 20250}
 
 		// Act
-		res := cap.CalculateCap(p.Price(), discount.UniversalDiscount.Amount.Value)
+		res, err := cap.CalculateCap(p.Price(), discount.Rules[0].Amount)
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 
 	})
@@ -50,17 +53,18 @@ func TestCalculateCap(t *testing.T) {
 	// cap set to zero will be changed to a value of 100, therefore removing it, there will be no cap, same applies to negative cap
 	t.Run("CALCULATE_CAP_PERCENTAGE_CAP_IS_ZERO", func(t *testing.T) {
 		// Arrange
-		cap := newCapPercentage(0)
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
-		discount := models.NewDiscount(*models.NewUniversalDiscount(20, models.NewMoney(currency.USD, 5)),
-			*models.NewSpecialDiscount(p.UPC(), 0, models.NewMoney(currency.USD, 0)), models.NoPrecedence)
+		cap := newCapPercentage(utils.WholePercentage(0))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+		discount := models.NewDiscount(*models.NewUniversalDiscount(utils.WholePercentage(20), models.NewMoney(currency.USD, "5")),
+			*models.NewSpecialDiscount(p.UPC(), utils.WholePercentage(0), models.NewMoney(currency.USD, "0")), models.NoPrecedence)
 
-		var expectedResult float64 = 5
+		expectedResult := models.NewMoney(currency.USD, "5")
 
 		// Act
-		res := cap.CalculateCap(p.Price(), discount.UniversalDiscount.Amount.Value)
+		res, err := cap.CalculateCap(p.Price(), discount.Rules[0].Amount)
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 
 	})
@@ -69,18 +73,19 @@ func TestCalculateCap(t *testing.T) {
 	// Cap set to zero will be changed to a value of 100, therefore removing it, same applies with negative cap
 	t.Run("CALCULATE_CAP_ABSOLUTE_VALUE_CAP_IS_ZERO", func(t *testing.T) {
 		// Arrange
-		cap := newCapAbsolute(0)
+		cap := newCapAbsolute(models.NewMoney(currency.USD, "0"))
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
-		discount := models.NewDiscount(*models.NewUniversalDiscount(20, models.NewMoney(currency.USD, 5)),
-			*models.NewSpecialDiscount(p.UPC(), 0, models.NewMoney(currency.USD, 0)), models.NoPrecedence)
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+		discount := models.NewDiscount(*models.NewUniversalDiscount(utils.WholePercentage(20), models.NewMoney(currency.USD, "5")),
+			*models.NewSpecialDiscount(p.UPC(), utils.WholePercentage(0), models.NewMoney(currency.USD, "0")), models.NoPrecedence)
 
-		var expectedResult float64 = 5
+		expectedResult := models.NewMoney(currency.USD, "5")
 
 		// Act
-		res := cap.CalculateCap(p.Price(), discount.UniversalDiscount.Amount.Value)
+		res, err := cap.CalculateCap(p.Price(), discount.Rules[0].Amount)
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 
 	})
@@ -88,17 +93,49 @@ func TestCalculateCap(t *testing.T) {
 	// Case for when discount is zero
 	t.Run("CALCULATE_CAP_ABSOLUTE_DISCOUNT_IS_ZERO", func(t *testing.T) {
 		// Arrange
-		cap := newCapAbsolute(5)
+		cap := newCapAbsolute(models.NewMoney(currency.USD, "5"))
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		var expectedResult float64 = 0
+		expectedResult := models.NewMoney(currency.USD, "0")
 
 		// Act
-		res := cap.CalculateCap(p.Price(), 0)
+		res, err := cap.CalculateCap(p.Price(), models.Money{})
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 
 	})
+
+	// Case for when the absolute cap is in a different currency than the discount
+	t.Run("CALCULATE_CAP_ABSOLUTE_CURRENCY_MISMATCH", func(t *testing.T) {
+		// Arrange
+		cap := newCapAbsolute(models.NewMoney(currency.USD, "5"))
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.GBP, "20.25"), models.NewCosts())
+
+		// Act
+		_, err := cap.CalculateCap(p.Price(), models.NewMoney(currency.GBP, "2"))
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+	})
+
+	// Case for when the absolute cap is set in the currency of the product
+	t.Run("CALCULATE_CAP_ABSOLUTE_IN_PRODUCT_CURRENCY", func(t *testing.T) {
+		// Arrange
+		cap := NewDiscountCapTesting(1, "3", currency.GBP)
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.GBP, "20.25"), models.NewCosts())
+
+		expectedResult := models.NewMoney(currency.GBP, "3")
+
+		// Act
+		res, err := cap.CalculateCap(p.Price(), models.NewMoney(currency.GBP, "4.05"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
 }
@@ -0,0 +1,36 @@
+package clock
+
+import "time"
+
+// Clock tells the current time, so calculations can be run at a fixed time in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock tells the time of the system
+type systemClock struct{}
+
+// fixedClock always tells the same time
+type fixedClock struct {
+	now time.Time
+}
+
+// System returns a clock that tells the time of the system
+func System() Clock {
+	return systemClock{}
+}
+
+// Fixed returns a clock that always tells the given time
+func Fixed(now time.Time) Clock {
+	return fixedClock{now: now}
+}
+
+// Now returns the current time of the system
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Now returns the fixed time of the clock
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
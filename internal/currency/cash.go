@@ -0,0 +1,21 @@
+package currency
+
+// cashIncrements stores the smallest amount, in minor units, that cash payments in a currency are rounded to.
+// Currencies that are not listed are paid in cash to the minor unit
+var cashIncrements = map[CurrencyCode]int64{
+	AUD: 5,
+	CAD: 5,
+	CHF: 5,
+	DKK: 50,
+	NOK: 100,
+	NZD: 10,
+	SEK: 100,
+}
+
+// CashIncrement returns the smallest amount, in minor units, that cash payments in the currency are rounded to
+func (c CurrencyCode) CashIncrement() int64 {
+	if increment, ok := cashIncrements[c]; ok {
+		return increment
+	}
+	return 1
+}
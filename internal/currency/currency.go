@@ -1,53 +1,102 @@
 package currency
 
-import "github.com/radoslavboychev/price-calculator-kata/config"
-
-// Enum for various currency code types
-const (
-	USD CurrencyCode = iota
-	GBP
-	JPY
-	EUR
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/radoslavboychev/price-calculator-kata/config"
 )
 
+// ErrUnknownCurrency is returned when a currency code is not part of the ISO 4217 registry
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// defaultMinorUnits is the amount of decimal places used for unknown currencies
+const defaultMinorUnits = 2
+
 // CurrencyCode defines an enum for the different currencies
 type CurrencyCode uint16
 
-// Currency stores the ISO-3 currency code
+// Currency stores the ISO 4217 details of a currency
 type Currency struct {
-	Code CurrencyCode
+	Code       CurrencyCode
+	Alpha      string
+	Numeric    uint16
+	MinorUnits int
+	Name       string
 }
 
-func NewCurrency(code uint16) *Currency {
-	return &Currency{
-		Code: CurrencyCode(code),
+// NewCurrency returns the currency with the specified currency code, returns an error if the code is unknown
+func NewCurrency(code uint16) (*Currency, error) {
+	c, err := CurrencyCode(code).Details()
+	if err != nil {
+		return nil, err
 	}
+	return &c, nil
 }
 
-// LoadCurrency reads the currency from the config and loads it
-func LoadCurrency() *Currency {
+// LoadCurrency reads the currency from the config and loads it, returns an error if the currency is unknown
+func LoadCurrency() (*Currency, error) {
 
 	conf := config.LoadConfig()
-	if conf.Currency > 3 {
-		conf.Currency = 0
+
+	c, err := Lookup(conf.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Lookup finds a currency by its alphabetic (e.g. "GBP") or numeric (e.g. "826") ISO 4217 code
+func Lookup(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	numeric, err := strconv.ParseUint(code, 10, 16)
+	for _, c := range registry {
+		if c.Alpha == code || (err == nil && uint64(c.Numeric) == numeric) {
+			return c, nil
+		}
 	}
 
-	return &Currency{
-		Code: CurrencyCode(conf.Currency),
+	return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+}
+
+// ParseCurrencyCode returns the currency code of an alphabetic or numeric ISO 4217 code
+func ParseCurrencyCode(code string) (CurrencyCode, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return 0, err
+	}
+	return c.Code, nil
+}
+
+// Details returns the ISO 4217 details of the currency, returns an error if the code is unknown
+func (c CurrencyCode) Details() (Currency, error) {
+	if !c.Valid() {
+		return Currency{}, fmt.Errorf("%w: %d", ErrUnknownCurrency, c)
+	}
+	return registry[c], nil
+}
+
+// Valid checks if the currency code is one of the supported currencies
+func (c CurrencyCode) Valid() bool {
+	return int(c) < len(registry)
+}
+
+// MinorUnits returns the amount of decimal places amounts in the currency are rounded to
+func (c CurrencyCode) MinorUnits() int {
+	if !c.Valid() {
+		return defaultMinorUnits
 	}
+	return registry[c].MinorUnits
 }
 
 // String repreents a currency as string for printing purposes
 func (c CurrencyCode) String() string {
-	switch c {
-	case USD:
-		return "USD"
-	case GBP:
-		return "GBP"
-	case JPY:
-		return "JPY"
-	case EUR:
-		return "EUR"
+	if !c.Valid() {
+		return "UNKNOWN CURRENCY"
 	}
-	return "UNKNOWN CURRENCY"
+	return registry[c].Alpha
 }
@@ -12,45 +12,138 @@ func TestLoadCurrency(t *testing.T) {
 
 		// Arrange
 		conf := config.LoadConfig()
-		conf.Currency = 0
 
-		expectedCurrencyCode := conf.Currency
-		expectedResult := NewCurrency(expectedCurrencyCode)
+		expectedResult, err := Lookup(conf.Currency)
+		assert.NoError(t, err)
 
 		// Act
-		res := LoadCurrency()
-		res.Code = 0
+		res, err := LoadCurrency()
 
 		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &expectedResult, res)
+	})
+
+	t.Run("NEW_CURRENCY_INVALID_CODE", func(t *testing.T) {
+
+		// Act
+		res, err := NewCurrency(uint16(len(registry)))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownCurrency)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Run("LOOKUP_ALPHABETIC_CODE", func(t *testing.T) {
+		// Arrange
+		expectedResult := Currency{Code: CHF, Alpha: "CHF", Numeric: 756, MinorUnits: 2, Name: "Swiss Franc"}
+
+		// Act
+		res, err := Lookup("chf")
+
+		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 	})
 
-	t.Run("LOAD_CURRENCY_INVALID_CODE", func(t *testing.T) {
+	t.Run("LOOKUP_NUMERIC_CODE", func(t *testing.T) {
+		// Arrange
+		expectedResult := BHD
+
+		// Act
+		res, err := ParseCurrencyCode("048")
 
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("LOOKUP_UNKNOWN_CODE", func(t *testing.T) {
+		// Act
+		_, err := Lookup("XYZ")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownCurrency)
+	})
+
+	t.Run("LOOKUP_REGISTRY_IS_CONSISTENT", func(t *testing.T) {
+		// every registry entry is stored at the index of its own currency code
+		for i, c := range registry {
+			assert.Equal(t, CurrencyCode(i), c.Code)
+			assert.Len(t, c.Alpha, 3)
+			assert.Equal(t, c.Alpha, c.Code.String())
+		}
+	})
+}
+
+func TestMinorUnits(t *testing.T) {
+	t.Run("MINOR_UNITS_TWO_DECIMALS", func(t *testing.T) {
 		// Arrange
-		conf := config.LoadConfig()
-		conf.Currency = 0
+		expectedResult := 2
+
+		// Act
+		res := USD.MinorUnits()
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
 
-		expectedCurrencyCode := conf.Currency
-		expectedResult := NewCurrency(expectedCurrencyCode)
+	t.Run("MINOR_UNITS_NO_DECIMALS", func(t *testing.T) {
+		// Arrange
+		expectedResult := 0
 
 		// Act
-		res := loadCurrencyTest(7)
+		res := JPY.MinorUnits()
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MINOR_UNITS_THREE_DECIMALS", func(t *testing.T) {
+		// Arrange
+		expectedResult := 3
+
+		// Act
+		res := KWD.MinorUnits()
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MINOR_UNITS_UNKNOWN_CURRENCY", func(t *testing.T) {
+		// Arrange
+		expectedResult := 2
+
+		// Act
+		res := CurrencyCode(500).MinorUnits()
 
 		// Assert
 		assert.Equal(t, expectedResult, res)
 	})
 }
 
-// loadCurrencyTest loads a currency with a specific currency code
-// if the code is bigger than the final iota, it gets set to 0 (default)
-func loadCurrencyTest(code uint16) *Currency {
+func TestCashIncrement(t *testing.T) {
+	t.Run("CASH_INCREMENT_LISTED", func(t *testing.T) {
+		// Arrange
+		var expectedResult int64 = 5
+
+		// Act
+		res := CHF.CashIncrement()
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("CASH_INCREMENT_DEFAULT", func(t *testing.T) {
+		// Arrange
+		var expectedResult int64 = 1
 
-	if code > 3 {
-		code = 0
-	}
+		// Act
+		res := USD.CashIncrement()
 
-	return &Currency{
-		Code: CurrencyCode(code),
-	}
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
 }
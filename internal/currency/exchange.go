@@ -0,0 +1,232 @@
+package currency
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/clock"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+)
+
+// RatePrecision is the amount of decimal places exchange rates are stored with
+const RatePrecision = 8
+
+// rateDateLayout is the layout of the dates in exchange rate files
+const rateDateLayout = "2006-01-02"
+
+// ErrRateNotFound is returned when there is no exchange rate between two currencies
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// ErrInvalidRate is returned when an exchange rate can not be parsed or is not positive
+var ErrInvalidRate = errors.New("invalid exchange rate")
+
+// ExchangeRate stores the price of one unit of the base currency in the quote currency,
+// as an integer with RatePrecision decimals, e.g. 1 EUR = 0.8535 GBP is stored as 85350000
+type ExchangeRate struct {
+	Base  CurrencyCode
+	Quote CurrencyCode
+	Rate  int64
+	Date  time.Time
+}
+
+// currencyPair is the key exchange rates are stored by
+type currencyPair struct {
+	base  CurrencyCode
+	quote CurrencyCode
+}
+
+// Converter finds exchange rates between currencies from a local rate table.
+// Every currency pair holds a time series of rates sorted by their effective date.
+// Rates that are not in the table are calculated by triangulating through the base currency
+type Converter struct {
+	base  CurrencyCode
+	rates map[currencyPair][]ExchangeRate
+	clock clock.Clock
+}
+
+// NewConverter constructor function for converters. If a currency pair has multiple rates for the same date, the last one is used
+func NewConverter(base CurrencyCode, rates ...ExchangeRate) *Converter {
+	c := &Converter{
+		base:  base,
+		rates: map[currencyPair][]ExchangeRate{},
+		clock: clock.System(),
+	}
+
+	for _, r := range rates {
+		key := currencyPair{base: r.Base, quote: r.Quote}
+		series := c.rates[key]
+
+		// keep the series sorted by date, replacing rates with the same date
+		i := sort.Search(len(series), func(i int) bool { return !series[i].Date.Before(r.Date) })
+		if i < len(series) && series[i].Date.Equal(r.Date) {
+			series[i] = r
+			continue
+		}
+		series = append(series, ExchangeRate{})
+		copy(series[i+1:], series[i:])
+		series[i] = r
+		c.rates[key] = series
+	}
+
+	return c
+}
+
+// WithClock sets the clock that tells the current time, rates dated after it are not effective yet. Uses the system clock by default
+func (c *Converter) WithClock(clk clock.Clock) *Converter {
+	c.clock = clk
+	return c
+}
+
+// NewExchangeRate parses an exchange rate from its decimal string representation, e.g. "0.8535"
+func NewExchangeRate(base, quote CurrencyCode, rate string, date time.Time) (ExchangeRate, error) {
+	value, err := format.ParseDecimal(rate, RatePrecision)
+	if err != nil || value <= 0 {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+
+	return ExchangeRate{
+		Base:  base,
+		Quote: quote,
+		Rate:  value,
+		Date:  date,
+	}, nil
+}
+
+// LoadRates reads exchange rates from a CSV file with the columns base,quote,rate,date (e.g. EUR,GBP,0.8535,2026-10-16).
+// A header row is skipped
+func LoadRates(path string) ([]ExchangeRate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadRates(f)
+}
+
+// ReadRates reads exchange rates in the CSV format described by LoadRates
+func ReadRates(r io.Reader) ([]ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []ExchangeRate
+	for i, record := range records {
+		// skip the header row
+		if i == 0 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+
+		base, err := ParseCurrencyCode(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		quote, err := ParseCurrencyCode(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		date, err := time.Parse(rateDateLayout, record[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rate, err := NewExchangeRate(base, quote, record[2], date)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// Rate returns the exchange rate from one currency to another that is effective at the current time, with RatePrecision decimals.
+// Uses the direct rate, the inverse of the opposite rate, or a cross rate through the base currency
+func (c *Converter) Rate(from, to CurrencyCode) (int64, error) {
+	return c.RateAt(from, to, time.Time{})
+}
+
+// RateAt returns the exchange rate from one currency to another that was effective at the given time,
+// which is the latest rate dated on or before it. A zero time uses the rate effective at the current time of the clock
+func (c *Converter) RateAt(from, to CurrencyCode, at time.Time) (int64, error) {
+	if from == to {
+		return format.Pow10(RatePrecision), nil
+	}
+
+	if at.IsZero() {
+		at = c.clock.Now()
+	}
+
+	if rate, ok := c.pairRate(from, to, at); ok {
+		return rate, nil
+	}
+
+	// triangulate through the base currency
+	fromBase, okFrom := c.pairRate(from, c.base, at)
+	baseTo, okTo := c.pairRate(c.base, to, at)
+	if okFrom && okTo {
+		return format.MulDivRoundChecked(fromBase, baseTo, format.Pow10(RatePrecision))
+	}
+
+	return 0, fmt.Errorf("%w: %v to %v on %v", ErrRateNotFound, from, to, at.Format(rateDateLayout))
+}
+
+// pairRate returns the direct rate between two currencies or the inverse of the opposite rate, effective at the given time
+func (c *Converter) pairRate(from, to CurrencyCode, at time.Time) (int64, bool) {
+	if from == to {
+		return format.Pow10(RatePrecision), true
+	}
+
+	if r, ok := c.effectiveRate(currencyPair{base: from, quote: to}, at); ok {
+		return r.Rate, true
+	}
+
+	if r, ok := c.effectiveRate(currencyPair{base: to, quote: from}, at); ok {
+		one := format.Pow10(RatePrecision)
+		return format.MulDivRound(one, one, r.Rate), true
+	}
+
+	return 0, false
+}
+
+// effectiveRate returns the latest rate of a currency pair dated on or before the given time
+func (c *Converter) effectiveRate(pair currencyPair, at time.Time) (ExchangeRate, bool) {
+	series := c.rates[pair]
+	if len(series) == 0 {
+		return ExchangeRate{}, false
+	}
+
+	// index of the first rate dated after the given time
+	i := sort.Search(len(series), func(i int) bool { return series[i].Date.After(at) })
+	if i == 0 {
+		return ExchangeRate{}, false
+	}
+
+	return series[i-1], true
+}
+
+// FormatRate formats an exchange rate with RatePrecision decimals without its trailing zeros, e.g. 85350000 as "0.8535"
+func FormatRate(rate int64) string {
+	str := format.FormatUnits(rate, RatePrecision, RatePrecision)
+	str = strings.TrimRight(str, "0")
+	return strings.TrimSuffix(str, ".")
+}
+
+// Base returns the base currency used for triangulation
+func (c *Converter) Base() CurrencyCode {
+	return c.base
+}
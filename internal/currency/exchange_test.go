@@ -0,0 +1,234 @@
+package currency
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRates = `base,quote,rate,date
+EUR,GBP,0.8535,2026-10-16
+EUR,USD,1.0850,2026-10-16
+EUR,JPY,162.35,2026-10-16
+EUR,GBP,0.8500,2026-10-01
+`
+
+func TestReadRates(t *testing.T) {
+	t.Run("READ_RATES_DEFAULT", func(t *testing.T) {
+		// Arrange
+		expectedRate := ExchangeRate{Base: EUR, Quote: GBP, Rate: 85350000, Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)}
+
+		// Act
+		rates, err := ReadRates(strings.NewReader(testRates))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, rates, 4)
+		assert.Equal(t, expectedRate, rates[0])
+	})
+
+	t.Run("READ_RATES_UNKNOWN_CURRENCY", func(t *testing.T) {
+		// Act
+		_, err := ReadRates(strings.NewReader("EUR,XYZ,1.5,2026-10-16\n"))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownCurrency)
+	})
+
+	t.Run("READ_RATES_INVALID_RATE", func(t *testing.T) {
+		// Act
+		_, err := ReadRates(strings.NewReader("EUR,GBP,-1,2026-10-16\n"))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidRate)
+	})
+}
+
+func TestConverterRate(t *testing.T) {
+	rates, _ := ReadRates(strings.NewReader(testRates))
+	converter := NewConverter(EUR, rates...)
+
+	t.Run("RATE_DIRECT_USES_LATEST", func(t *testing.T) {
+		// Arrange
+		var expectedResult int64 = 85350000
+
+		// Act
+		res, err := converter.Rate(EUR, GBP)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_INVERSE", func(t *testing.T) {
+		// Arrange
+		// 1 / 0.8535 = 1.17164616...
+		var expectedResult int64 = 117164616
+
+		// Act
+		res, err := converter.Rate(GBP, EUR)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_CROSS_THROUGH_BASE", func(t *testing.T) {
+		// Arrange
+		// GBP -> EUR -> USD = 1.17164616 * 1.0850 = 1.27123608...
+		var expectedResult int64 = 127123608
+
+		// Act
+		res, err := converter.Rate(GBP, USD)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_SAME_CURRENCY", func(t *testing.T) {
+		// Arrange
+		var expectedResult int64 = 100000000
+
+		// Act
+		res, err := converter.Rate(CHF, CHF)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_NOT_FOUND", func(t *testing.T) {
+		// Act
+		_, err := converter.Rate(GBP, CHF)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+}
+
+func TestConverterRateAt(t *testing.T) {
+	rates, _ := ReadRates(strings.NewReader(testRates))
+	converter := NewConverter(EUR, rates...)
+
+	t.Run("RATE_AT_LATEST_ON_OR_BEFORE", func(t *testing.T) {
+		// Arrange
+		at := time.Date(2026, 10, 10, 15, 30, 0, 0, time.UTC)
+		var expectedResult int64 = 85000000
+
+		// Act
+		res, err := converter.RateAt(EUR, GBP, at)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_AT_EFFECTIVE_DATE", func(t *testing.T) {
+		// Arrange
+		at := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+		var expectedResult int64 = 85350000
+
+		// Act
+		res, err := converter.RateAt(EUR, GBP, at)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_AT_INVERSE", func(t *testing.T) {
+		// Arrange
+		at := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+		// 1 / 0.85 = 1.17647058...
+		var expectedResult int64 = 117647059
+
+		// Act
+		res, err := converter.RateAt(GBP, EUR, at)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_AT_BEFORE_FIRST_RATE", func(t *testing.T) {
+		// Arrange
+		at := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		_, err := converter.RateAt(EUR, GBP, at)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+
+	t.Run("RATE_AT_CROSS_RATE_NOT_YET_EFFECTIVE", func(t *testing.T) {
+		// Arrange
+		// the EUR/USD rate only becomes effective on 2026-10-16
+		at := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		_, err := converter.RateAt(GBP, USD, at)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+
+	t.Run("RATE_AT_SAME_DATE_REPLACED", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+		first, _ := NewExchangeRate(EUR, CHF, "0.9400", date)
+		second, _ := NewExchangeRate(EUR, CHF, "0.9410", date)
+		var expectedResult int64 = 94100000
+
+		// Act
+		res, err := NewConverter(EUR, first, second).RateAt(EUR, CHF, date)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RATE_FUTURE_RATE_NOT_EFFECTIVE", func(t *testing.T) {
+		// Arrange
+		// the 0.8535 rate is published ahead of 2026-10-16, so the 0.8500 rate is still effective today
+		today := time.Date(2026, 10, 10, 15, 30, 0, 0, time.UTC)
+		var expectedResult int64 = 85000000
+
+		// Act
+		res, err := NewConverter(EUR, rates...).WithClock(clock.Fixed(today)).Rate(EUR, GBP)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+}
+
+func TestFormatRate(t *testing.T) {
+	t.Run("FORMAT_RATE_TRIMS_ZEROS", func(t *testing.T) {
+		// Arrange
+		var rate int64 = 85350000
+		expectedResult := "0.8535"
+
+		// Act
+		res := FormatRate(rate)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("FORMAT_RATE_WHOLE_NUMBER", func(t *testing.T) {
+		// Arrange
+		var rate int64 = 16200000000
+		expectedResult := "162"
+
+		// Act
+		res := FormatRate(rate)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+}
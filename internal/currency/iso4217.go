@@ -0,0 +1,340 @@
+package currency
+
+// Enum for the ISO 4217 currency codes. The first currencies keep their original values,
+// the rest follow in alphabetical order
+const (
+	USD CurrencyCode = iota
+	GBP
+	JPY
+	EUR
+	BHD
+	KWD
+	AED
+	AFN
+	ALL
+	AMD
+	ANG
+	AOA
+	ARS
+	AUD
+	AWG
+	AZN
+	BAM
+	BBD
+	BDT
+	BGN
+	BIF
+	BMD
+	BND
+	BOB
+	BOV
+	BRL
+	BSD
+	BTN
+	BWP
+	BYN
+	BZD
+	CAD
+	CDF
+	CHE
+	CHF
+	CHW
+	CLF
+	CLP
+	CNY
+	COP
+	COU
+	CRC
+	CUP
+	CVE
+	CZK
+	DJF
+	DKK
+	DOP
+	DZD
+	EGP
+	ERN
+	ETB
+	FJD
+	FKP
+	GEL
+	GHS
+	GIP
+	GMD
+	GNF
+	GTQ
+	GYD
+	HKD
+	HNL
+	HTG
+	HUF
+	IDR
+	ILS
+	INR
+	IQD
+	IRR
+	ISK
+	JMD
+	JOD
+	KES
+	KGS
+	KHR
+	KMF
+	KPW
+	KRW
+	KYD
+	KZT
+	LAK
+	LBP
+	LKR
+	LRD
+	LSL
+	LYD
+	MAD
+	MDL
+	MGA
+	MKD
+	MMK
+	MNT
+	MOP
+	MRU
+	MUR
+	MVR
+	MWK
+	MXN
+	MXV
+	MYR
+	MZN
+	NAD
+	NGN
+	NIO
+	NOK
+	NPR
+	NZD
+	OMR
+	PAB
+	PEN
+	PGK
+	PHP
+	PKR
+	PLN
+	PYG
+	QAR
+	RON
+	RSD
+	RUB
+	RWF
+	SAR
+	SBD
+	SCR
+	SDG
+	SEK
+	SGD
+	SHP
+	SLE
+	SOS
+	SRD
+	SSP
+	STN
+	SVC
+	SYP
+	SZL
+	THB
+	TJS
+	TMT
+	TND
+	TOP
+	TRY
+	TTD
+	TWD
+	TZS
+	UAH
+	UGX
+	USN
+	UYI
+	UYU
+	UYW
+	UZS
+	VED
+	VES
+	VND
+	VUV
+	WST
+	XAF
+	XCD
+	XOF
+	XPF
+	YER
+	ZAR
+	ZMW
+	ZWG
+)
+
+// registry stores the ISO 4217 details of every currency, indexed by its currency code
+var registry = []Currency{
+	{Code: USD, Alpha: "USD", Numeric: 840, MinorUnits: 2, Name: "US Dollar"},
+	{Code: GBP, Alpha: "GBP", Numeric: 826, MinorUnits: 2, Name: "Pound Sterling"},
+	{Code: JPY, Alpha: "JPY", Numeric: 392, MinorUnits: 0, Name: "Yen"},
+	{Code: EUR, Alpha: "EUR", Numeric: 978, MinorUnits: 2, Name: "Euro"},
+	{Code: BHD, Alpha: "BHD", Numeric: 48, MinorUnits: 3, Name: "Bahraini Dinar"},
+	{Code: KWD, Alpha: "KWD", Numeric: 414, MinorUnits: 3, Name: "Kuwaiti Dinar"},
+	{Code: AED, Alpha: "AED", Numeric: 784, MinorUnits: 2, Name: "UAE Dirham"},
+	{Code: AFN, Alpha: "AFN", Numeric: 971, MinorUnits: 2, Name: "Afghani"},
+	{Code: ALL, Alpha: "ALL", Numeric: 8, MinorUnits: 2, Name: "Lek"},
+	{Code: AMD, Alpha: "AMD", Numeric: 51, MinorUnits: 2, Name: "Armenian Dram"},
+	{Code: ANG, Alpha: "ANG", Numeric: 532, MinorUnits: 2, Name: "Netherlands Antillean Guilder"},
+	{Code: AOA, Alpha: "AOA", Numeric: 973, MinorUnits: 2, Name: "Kwanza"},
+	{Code: ARS, Alpha: "ARS", Numeric: 32, MinorUnits: 2, Name: "Argentine Peso"},
+	{Code: AUD, Alpha: "AUD", Numeric: 36, MinorUnits: 2, Name: "Australian Dollar"},
+	{Code: AWG, Alpha: "AWG", Numeric: 533, MinorUnits: 2, Name: "Aruban Florin"},
+	{Code: AZN, Alpha: "AZN", Numeric: 944, MinorUnits: 2, Name: "Azerbaijan Manat"},
+	{Code: BAM, Alpha: "BAM", Numeric: 977, MinorUnits: 2, Name: "Convertible Mark"},
+	{Code: BBD, Alpha: "BBD", Numeric: 52, MinorUnits: 2, Name: "Barbados Dollar"},
+	{Code: BDT, Alpha: "BDT", Numeric: 50, MinorUnits: 2, Name: "Taka"},
+	{Code: BGN, Alpha: "BGN", Numeric: 975, MinorUnits: 2, Name: "Bulgarian Lev"},
+	{Code: BIF, Alpha: "BIF", Numeric: 108, MinorUnits: 0, Name: "Burundi Franc"},
+	{Code: BMD, Alpha: "BMD", Numeric: 60, MinorUnits: 2, Name: "Bermudian Dollar"},
+	{Code: BND, Alpha: "BND", Numeric: 96, MinorUnits: 2, Name: "Brunei Dollar"},
+	{Code: BOB, Alpha: "BOB", Numeric: 68, MinorUnits: 2, Name: "Boliviano"},
+	{Code: BOV, Alpha: "BOV", Numeric: 984, MinorUnits: 2, Name: "Mvdol"},
+	{Code: BRL, Alpha: "BRL", Numeric: 986, MinorUnits: 2, Name: "Brazilian Real"},
+	{Code: BSD, Alpha: "BSD", Numeric: 44, MinorUnits: 2, Name: "Bahamian Dollar"},
+	{Code: BTN, Alpha: "BTN", Numeric: 64, MinorUnits: 2, Name: "Ngultrum"},
+	{Code: BWP, Alpha: "BWP", Numeric: 72, MinorUnits: 2, Name: "Pula"},
+	{Code: BYN, Alpha: "BYN", Numeric: 933, MinorUnits: 2, Name: "Belarusian Ruble"},
+	{Code: BZD, Alpha: "BZD", Numeric: 84, MinorUnits: 2, Name: "Belize Dollar"},
+	{Code: CAD, Alpha: "CAD", Numeric: 124, MinorUnits: 2, Name: "Canadian Dollar"},
+	{Code: CDF, Alpha: "CDF", Numeric: 976, MinorUnits: 2, Name: "Congolese Franc"},
+	{Code: CHE, Alpha: "CHE", Numeric: 947, MinorUnits: 2, Name: "WIR Euro"},
+	{Code: CHF, Alpha: "CHF", Numeric: 756, MinorUnits: 2, Name: "Swiss Franc"},
+	{Code: CHW, Alpha: "CHW", Numeric: 948, MinorUnits: 2, Name: "WIR Franc"},
+	{Code: CLF, Alpha: "CLF", Numeric: 990, MinorUnits: 4, Name: "Unidad de Fomento"},
+	{Code: CLP, Alpha: "CLP", Numeric: 152, MinorUnits: 0, Name: "Chilean Peso"},
+	{Code: CNY, Alpha: "CNY", Numeric: 156, MinorUnits: 2, Name: "Yuan Renminbi"},
+	{Code: COP, Alpha: "COP", Numeric: 170, MinorUnits: 2, Name: "Colombian Peso"},
+	{Code: COU, Alpha: "COU", Numeric: 970, MinorUnits: 2, Name: "Unidad de Valor Real"},
+	{Code: CRC, Alpha: "CRC", Numeric: 188, MinorUnits: 2, Name: "Costa Rican Colon"},
+	{Code: CUP, Alpha: "CUP", Numeric: 192, MinorUnits: 2, Name: "Cuban Peso"},
+	{Code: CVE, Alpha: "CVE", Numeric: 132, MinorUnits: 2, Name: "Cabo Verde Escudo"},
+	{Code: CZK, Alpha: "CZK", Numeric: 203, MinorUnits: 2, Name: "Czech Koruna"},
+	{Code: DJF, Alpha: "DJF", Numeric: 262, MinorUnits: 0, Name: "Djibouti Franc"},
+	{Code: DKK, Alpha: "DKK", Numeric: 208, MinorUnits: 2, Name: "Danish Krone"},
+	{Code: DOP, Alpha: "DOP", Numeric: 214, MinorUnits: 2, Name: "Dominican Peso"},
+	{Code: DZD, Alpha: "DZD", Numeric: 12, MinorUnits: 2, Name: "Algerian Dinar"},
+	{Code: EGP, Alpha: "EGP", Numeric: 818, MinorUnits: 2, Name: "Egyptian Pound"},
+	{Code: ERN, Alpha: "ERN", Numeric: 232, MinorUnits: 2, Name: "Nakfa"},
+	{Code: ETB, Alpha: "ETB", Numeric: 230, MinorUnits: 2, Name: "Ethiopian Birr"},
+	{Code: FJD, Alpha: "FJD", Numeric: 242, MinorUnits: 2, Name: "Fiji Dollar"},
+	{Code: FKP, Alpha: "FKP", Numeric: 238, MinorUnits: 2, Name: "Falkland Islands Pound"},
+	{Code: GEL, Alpha: "GEL", Numeric: 981, MinorUnits: 2, Name: "Lari"},
+	{Code: GHS, Alpha: "GHS", Numeric: 936, MinorUnits: 2, Name: "Ghana Cedi"},
+	{Code: GIP, Alpha: "GIP", Numeric: 292, MinorUnits: 2, Name: "Gibraltar Pound"},
+	{Code: GMD, Alpha: "GMD", Numeric: 270, MinorUnits: 2, Name: "Dalasi"},
+	{Code: GNF, Alpha: "GNF", Numeric: 324, MinorUnits: 0, Name: "Guinean Franc"},
+	{Code: GTQ, Alpha: "GTQ", Numeric: 320, MinorUnits: 2, Name: "Quetzal"},
+	{Code: GYD, Alpha: "GYD", Numeric: 328, MinorUnits: 2, Name: "Guyana Dollar"},
+	{Code: HKD, Alpha: "HKD", Numeric: 344, MinorUnits: 2, Name: "Hong Kong Dollar"},
+	{Code: HNL, Alpha: "HNL", Numeric: 340, MinorUnits: 2, Name: "Lempira"},
+	{Code: HTG, Alpha: "HTG", Numeric: 332, MinorUnits: 2, Name: "Gourde"},
+	{Code: HUF, Alpha: "HUF", Numeric: 348, MinorUnits: 2, Name: "Forint"},
+	{Code: IDR, Alpha: "IDR", Numeric: 360, MinorUnits: 2, Name: "Rupiah"},
+	{Code: ILS, Alpha: "ILS", Numeric: 376, MinorUnits: 2, Name: "New Israeli Sheqel"},
+	{Code: INR, Alpha: "INR", Numeric: 356, MinorUnits: 2, Name: "Indian Rupee"},
+	{Code: IQD, Alpha: "IQD", Numeric: 368, MinorUnits: 3, Name: "Iraqi Dinar"},
+	{Code: IRR, Alpha: "IRR", Numeric: 364, MinorUnits: 2, Name: "Iranian Rial"},
+	{Code: ISK, Alpha: "ISK", Numeric: 352, MinorUnits: 0, Name: "Iceland Krona"},
+	{Code: JMD, Alpha: "JMD", Numeric: 388, MinorUnits: 2, Name: "Jamaican Dollar"},
+	{Code: JOD, Alpha: "JOD", Numeric: 400, MinorUnits: 3, Name: "Jordanian Dinar"},
+	{Code: KES, Alpha: "KES", Numeric: 404, MinorUnits: 2, Name: "Kenyan Shilling"},
+	{Code: KGS, Alpha: "KGS", Numeric: 417, MinorUnits: 2, Name: "Som"},
+	{Code: KHR, Alpha: "KHR", Numeric: 116, MinorUnits: 2, Name: "Riel"},
+	{Code: KMF, Alpha: "KMF", Numeric: 174, MinorUnits: 0, Name: "Comorian Franc"},
+	{Code: KPW, Alpha: "KPW", Numeric: 408, MinorUnits: 2, Name: "North Korean Won"},
+	{Code: KRW, Alpha: "KRW", Numeric: 410, MinorUnits: 0, Name: "Won"},
+	{Code: KYD, Alpha: "KYD", Numeric: 136, MinorUnits: 2, Name: "Cayman Islands Dollar"},
+	{Code: KZT, Alpha: "KZT", Numeric: 398, MinorUnits: 2, Name: "Tenge"},
+	{Code: LAK, Alpha: "LAK", Numeric: 418, MinorUnits: 2, Name: "Lao Kip"},
+	{Code: LBP, Alpha: "LBP", Numeric: 422, MinorUnits: 2, Name: "Lebanese Pound"},
+	{Code: LKR, Alpha: "LKR", Numeric: 144, MinorUnits: 2, Name: "Sri Lanka Rupee"},
+	{Code: LRD, Alpha: "LRD", Numeric: 430, MinorUnits: 2, Name: "Liberian Dollar"},
+	{Code: LSL, Alpha: "LSL", Numeric: 426, MinorUnits: 2, Name: "Loti"},
+	{Code: LYD, Alpha: "LYD", Numeric: 434, MinorUnits: 3, Name: "Libyan Dinar"},
+	{Code: MAD, Alpha: "MAD", Numeric: 504, MinorUnits: 2, Name: "Moroccan Dirham"},
+	{Code: MDL, Alpha: "MDL", Numeric: 498, MinorUnits: 2, Name: "Moldovan Leu"},
+	{Code: MGA, Alpha: "MGA", Numeric: 969, MinorUnits: 2, Name: "Malagasy Ariary"},
+	{Code: MKD, Alpha: "MKD", Numeric: 807, MinorUnits: 2, Name: "Denar"},
+	{Code: MMK, Alpha: "MMK", Numeric: 104, MinorUnits: 2, Name: "Kyat"},
+	{Code: MNT, Alpha: "MNT", Numeric: 496, MinorUnits: 2, Name: "Tugrik"},
+	{Code: MOP, Alpha: "MOP", Numeric: 446, MinorUnits: 2, Name: "Pataca"},
+	{Code: MRU, Alpha: "MRU", Numeric: 929, MinorUnits: 2, Name: "Ouguiya"},
+	{Code: MUR, Alpha: "MUR", Numeric: 480, MinorUnits: 2, Name: "Mauritius Rupee"},
+	{Code: MVR, Alpha: "MVR", Numeric: 462, MinorUnits: 2, Name: "Rufiyaa"},
+	{Code: MWK, Alpha: "MWK", Numeric: 454, MinorUnits: 2, Name: "Malawi Kwacha"},
+	{Code: MXN, Alpha: "MXN", Numeric: 484, MinorUnits: 2, Name: "Mexican Peso"},
+	{Code: MXV, Alpha: "MXV", Numeric: 979, MinorUnits: 2, Name: "Mexican Unidad de Inversion (UDI)"},
+	{Code: MYR, Alpha: "MYR", Numeric: 458, MinorUnits: 2, Name: "Malaysian Ringgit"},
+	{Code: MZN, Alpha: "MZN", Numeric: 943, MinorUnits: 2, Name: "Mozambique Metical"},
+	{Code: NAD, Alpha: "NAD", Numeric: 516, MinorUnits: 2, Name: "Namibia Dollar"},
+	{Code: NGN, Alpha: "NGN", Numeric: 566, MinorUnits: 2, Name: "Naira"},
+	{Code: NIO, Alpha: "NIO", Numeric: 558, MinorUnits: 2, Name: "Cordoba Oro"},
+	{Code: NOK, Alpha: "NOK", Numeric: 578, MinorUnits: 2, Name: "Norwegian Krone"},
+	{Code: NPR, Alpha: "NPR", Numeric: 524, MinorUnits: 2, Name: "Nepalese Rupee"},
+	{Code: NZD, Alpha: "NZD", Numeric: 554, MinorUnits: 2, Name: "New Zealand Dollar"},
+	{Code: OMR, Alpha: "OMR", Numeric: 512, MinorUnits: 3, Name: "Rial Omani"},
+	{Code: PAB, Alpha: "PAB", Numeric: 590, MinorUnits: 2, Name: "Balboa"},
+	{Code: PEN, Alpha: "PEN", Numeric: 604, MinorUnits: 2, Name: "Sol"},
+	{Code: PGK, Alpha: "PGK", Numeric: 598, MinorUnits: 2, Name: "Kina"},
+	{Code: PHP, Alpha: "PHP", Numeric: 608, MinorUnits: 2, Name: "Philippine Peso"},
+	{Code: PKR, Alpha: "PKR", Numeric: 586, MinorUnits: 2, Name: "Pakistan Rupee"},
+	{Code: PLN, Alpha: "PLN", Numeric: 985, MinorUnits: 2, Name: "Zloty"},
+	{Code: PYG, Alpha: "PYG", Numeric: 600, MinorUnits: 0, Name: "Guarani"},
+	{Code: QAR, Alpha: "QAR", Numeric: 634, MinorUnits: 2, Name: "Qatari Rial"},
+	{Code: RON, Alpha: "RON", Numeric: 946, MinorUnits: 2, Name: "Romanian Leu"},
+	{Code: RSD, Alpha: "RSD", Numeric: 941, MinorUnits: 2, Name: "Serbian Dinar"},
+	{Code: RUB, Alpha: "RUB", Numeric: 643, MinorUnits: 2, Name: "Russian Ruble"},
+	{Code: RWF, Alpha: "RWF", Numeric: 646, MinorUnits: 0, Name: "Rwanda Franc"},
+	{Code: SAR, Alpha: "SAR", Numeric: 682, MinorUnits: 2, Name: "Saudi Riyal"},
+	{Code: SBD, Alpha: "SBD", Numeric: 90, MinorUnits: 2, Name: "Solomon Islands Dollar"},
+	{Code: SCR, Alpha: "SCR", Numeric: 690, MinorUnits: 2, Name: "Seychelles Rupee"},
+	{Code: SDG, Alpha: "SDG", Numeric: 938, MinorUnits: 2, Name: "Sudanese Pound"},
+	{Code: SEK, Alpha: "SEK", Numeric: 752, MinorUnits: 2, Name: "Swedish Krona"},
+	{Code: SGD, Alpha: "SGD", Numeric: 702, MinorUnits: 2, Name: "Singapore Dollar"},
+	{Code: SHP, Alpha: "SHP", Numeric: 654, MinorUnits: 2, Name: "Saint Helena Pound"},
+	{Code: SLE, Alpha: "SLE", Numeric: 925, MinorUnits: 2, Name: "Leone"},
+	{Code: SOS, Alpha: "SOS", Numeric: 706, MinorUnits: 2, Name: "Somali Shilling"},
+	{Code: SRD, Alpha: "SRD", Numeric: 968, MinorUnits: 2, Name: "Surinam Dollar"},
+	{Code: SSP, Alpha: "SSP", Numeric: 728, MinorUnits: 2, Name: "South Sudanese Pound"},
+	{Code: STN, Alpha: "STN", Numeric: 930, MinorUnits: 2, Name: "Dobra"},
+	{Code: SVC, Alpha: "SVC", Numeric: 222, MinorUnits: 2, Name: "El Salvador Colon"},
+	{Code: SYP, Alpha: "SYP", Numeric: 760, MinorUnits: 2, Name: "Syrian Pound"},
+	{Code: SZL, Alpha: "SZL", Numeric: 748, MinorUnits: 2, Name: "Lilangeni"},
+	{Code: THB, Alpha: "THB", Numeric: 764, MinorUnits: 2, Name: "Baht"},
+	{Code: TJS, Alpha: "TJS", Numeric: 972, MinorUnits: 2, Name: "Somoni"},
+	{Code: TMT, Alpha: "TMT", Numeric: 934, MinorUnits: 2, Name: "Turkmenistan New Manat"},
+	{Code: TND, Alpha: "TND", Numeric: 788, MinorUnits: 3, Name: "Tunisian Dinar"},
+	{Code: TOP, Alpha: "TOP", Numeric: 776, MinorUnits: 2, Name: "Pa'anga"},
+	{Code: TRY, Alpha: "TRY", Numeric: 949, MinorUnits: 2, Name: "Turkish Lira"},
+	{Code: TTD, Alpha: "TTD", Numeric: 780, MinorUnits: 2, Name: "Trinidad and Tobago Dollar"},
+	{Code: TWD, Alpha: "TWD", Numeric: 901, MinorUnits: 2, Name: "New Taiwan Dollar"},
+	{Code: TZS, Alpha: "TZS", Numeric: 834, MinorUnits: 2, Name: "Tanzanian Shilling"},
+	{Code: UAH, Alpha: "UAH", Numeric: 980, MinorUnits: 2, Name: "Hryvnia"},
+	{Code: UGX, Alpha: "UGX", Numeric: 800, MinorUnits: 0, Name: "Uganda Shilling"},
+	{Code: USN, Alpha: "USN", Numeric: 997, MinorUnits: 2, Name: "US Dollar (Next day)"},
+	{Code: UYI, Alpha: "UYI", Numeric: 940, MinorUnits: 0, Name: "Uruguay Peso en Unidades Indexadas (UI)"},
+	{Code: UYU, Alpha: "UYU", Numeric: 858, MinorUnits: 2, Name: "Peso Uruguayo"},
+	{Code: UYW, Alpha: "UYW", Numeric: 927, MinorUnits: 4, Name: "Unidad Previsional"},
+	{Code: UZS, Alpha: "UZS", Numeric: 860, MinorUnits: 2, Name: "Uzbekistan Sum"},
+	{Code: VED, Alpha: "VED", Numeric: 926, MinorUnits: 2, Name: "Bolivar Soberano (digital)"},
+	{Code: VES, Alpha: "VES", Numeric: 928, MinorUnits: 2, Name: "Bolivar Soberano"},
+	{Code: VND, Alpha: "VND", Numeric: 704, MinorUnits: 0, Name: "Dong"},
+	{Code: VUV, Alpha: "VUV", Numeric: 548, MinorUnits: 0, Name: "Vatu"},
+	{Code: WST, Alpha: "WST", Numeric: 882, MinorUnits: 2, Name: "Tala"},
+	{Code: XAF, Alpha: "XAF", Numeric: 950, MinorUnits: 0, Name: "CFA Franc BEAC"},
+	{Code: XCD, Alpha: "XCD", Numeric: 951, MinorUnits: 2, Name: "East Caribbean Dollar"},
+	{Code: XOF, Alpha: "XOF", Numeric: 952, MinorUnits: 0, Name: "CFA Franc BCEAO"},
+	{Code: XPF, Alpha: "XPF", Numeric: 953, MinorUnits: 0, Name: "CFP Franc"},
+	{Code: YER, Alpha: "YER", Numeric: 886, MinorUnits: 2, Name: "Yemeni Rial"},
+	{Code: ZAR, Alpha: "ZAR", Numeric: 710, MinorUnits: 2, Name: "Rand"},
+	{Code: ZMW, Alpha: "ZMW", Numeric: 967, MinorUnits: 2, Name: "Zambian Kwacha"},
+	{Code: ZWG, Alpha: "ZWG", Numeric: 924, MinorUnits: 2, Name: "Zimbabwe Gold"},
+}
@@ -0,0 +1,117 @@
+package exemption
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+)
+
+// Certificate is a tax exemption certificate of a customer, e.g. of a non-profit or of a reseller buying for resale
+type Certificate struct {
+	Number string
+	Reason string
+	// Scope is the tax categories the certificate covers, an empty scope covers every category
+	Scope []models.TaxCategory
+	// Expires is the time the certificate stops being valid, a zero time never expires
+	Expires time.Time
+	// Exempt is the portion of the tax the customer is exempt from, e.g. 100% for no tax or 50% for half of the tax
+	Exempt utils.Percentage
+}
+
+// Exemption is the tax exemption a product is sold with
+type Exemption struct {
+	Reason string
+	Exempt utils.Percentage
+}
+
+// NewCertificate constructor function for certificates that fully exempt the customer from tax
+func NewCertificate(number, reason string, expires time.Time, scope ...models.TaxCategory) Certificate {
+	return NewPartialCertificate(number, reason, utils.HundredPercent, expires, scope...)
+}
+
+// NewPartialCertificate constructor function for certificates that exempt the customer from a portion of the tax,
+// so the product is taxed with a reduced rate. Portions outside of 0 to 100 percent are limited to that range
+func NewPartialCertificate(number, reason string, exempt utils.Percentage, expires time.Time, scope ...models.TaxCategory) Certificate {
+	if reason == "" {
+		reason = "Tax exemption certificate"
+	}
+
+	return Certificate{
+		Number:  number,
+		Reason:  reason,
+		Scope:   scope,
+		Expires: expires,
+		Exempt:  exempt.Clamp(),
+	}
+}
+
+// Valid checks if the certificate has not expired at the given time
+func (c Certificate) Valid(at time.Time) bool {
+	return c.Expires.IsZero() || at.Before(c.Expires)
+}
+
+// Covers checks if the tax category is in the scope of the certificate
+func (c Certificate) Covers(category models.TaxCategory) bool {
+	if len(c.Scope) == 0 {
+		return true
+	}
+
+	for _, s := range c.Scope {
+		if strings.EqualFold(strings.TrimSpace(string(s)), strings.TrimSpace(string(category))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns the exemption a product in the given tax category is sold with at the given time.
+// Exempt products are never taxed, otherwise the valid certificate covering the category that exempts the most tax is used.
+// Returns false if the product is taxed in full
+func Resolve(p *models.Product, category models.TaxCategory, at time.Time, certificates ...Certificate) (Exemption, bool) {
+	if p.IsTaxExempt() {
+		return Exemption{Reason: p.TaxExemptionReason(), Exempt: utils.HundredPercent}, true
+	}
+
+	var best *Certificate
+	for i, c := range certificates {
+		if c.Exempt == 0 || !c.Valid(at) || !c.Covers(category) {
+			continue
+		}
+		if best == nil || c.Exempt > best.Exempt {
+			best = &certificates[i]
+		}
+	}
+
+	if best == nil {
+		return Exemption{}, false
+	}
+
+	reason := best.Reason
+	if best.Number != "" {
+		reason = fmt.Sprintf("%v (certificate %v)", reason, best.Number)
+	}
+
+	return Exemption{Reason: reason, Exempt: best.Exempt}, true
+}
+
+// IsFull checks if the product is not taxed at all
+func (e Exemption) IsFull() bool {
+	return e.Exempt >= utils.HundredPercent
+}
+
+// Apply returns the taxes with their rates reduced by the exempt portion, fully exempt taxes have a 0% rate
+func (e Exemption) Apply(taxes []models.Tax) []models.Tax {
+	reduced := make([]models.Tax, len(taxes))
+	for i, tax := range taxes {
+		rate := tax.Rate() - utils.Percentage(utils.AmountFromPercentage(e.Exempt, int64(tax.Rate())))
+		if tax.IsCompound() {
+			reduced[i] = *models.NewCompoundTax(tax.Name(), rate)
+		} else {
+			reduced[i] = *models.NewSimpleTax(tax.Name(), rate)
+		}
+	}
+	return reduced
+}
@@ -0,0 +1,82 @@
+package exemption
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	at := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	t.Run("RESOLVE_EXEMPT_PRODUCT", func(t *testing.T) {
+		// Arrange
+		p := models.NewProduct("Insulin", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).
+			WithTaxExemption("")
+		partial := NewPartialCertificate("EDU-42", "Educational institution", utils.WholePercentage(50), time.Time{})
+
+		expectedResult := Exemption{Reason: "Tax exempt product", Exempt: utils.HundredPercent}
+
+		// Act
+		res, ok := Resolve(&p, models.CategoryStandard, at, partial)
+
+		// Assert
+		assert.True(t, ok)
+		assert.True(t, res.IsFull())
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RESOLVE_LARGEST_VALID_CERTIFICATE", func(t *testing.T) {
+		// Arrange
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+		expired := NewCertificate("NP-1", "Non-profit organization", at)
+		partial := NewPartialCertificate("EDU-42", "Educational institution", utils.WholePercentage(50), at.AddDate(1, 0, 0))
+		full := NewCertificate("RS-7", "Resale", time.Time{}, "BOOKS")
+
+		expectedResult := Exemption{Reason: "Resale (certificate RS-7)", Exempt: utils.HundredPercent}
+
+		// Act
+		res, ok := Resolve(&p, models.CategoryBooks, at, expired, partial, full)
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("RESOLVE_NO_EXEMPTION", func(t *testing.T) {
+		// Arrange
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+		outOfScope := NewCertificate("RS-7", "Resale", time.Time{}, models.CategoryFood)
+		none := NewPartialCertificate("NP-2", "Non-profit organization", 0, time.Time{})
+
+		// Act
+		_, ok := Resolve(&p, models.CategoryBooks, at, outOfScope, none)
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("APPLY_PARTIAL_EXEMPTION", func(t *testing.T) {
+		// Arrange
+		taxes := []models.Tax{
+			*models.NewSimpleTax("GST", utils.WholePercentage(5)),
+			*models.NewCompoundTax("QST", utils.Percentage(99750)),
+		}
+		e := Exemption{Reason: "Educational institution", Exempt: utils.WholePercentage(50)}
+
+		// Act
+		res := e.Apply(taxes)
+
+		// Assert
+		assert.Equal(t, utils.Percentage(25000), res[0].Rate())
+		assert.Equal(t, utils.Percentage(49875), res[1].Rate())
+		assert.Equal(t, "QST", res[1].Name())
+		assert.True(t, res[1].IsCompound())
+	})
+}
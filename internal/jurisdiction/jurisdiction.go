@@ -0,0 +1,182 @@
+package jurisdiction
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+)
+
+// Enum for the levels of jurisdictions that levy sales tax, in the order they are reported
+const (
+	LevelState Level = iota
+	LevelCounty
+	LevelCity
+	LevelSpecial
+)
+
+// ErrJurisdictionNotFound is returned when there are no tax rates for a destination
+var ErrJurisdictionNotFound = errors.New("jurisdiction not found")
+
+// ErrInvalidLevel is returned when a jurisdiction level can not be parsed
+var ErrInvalidLevel = errors.New("invalid jurisdiction level")
+
+// Level is the level of a jurisdiction, e.g. state or county
+type Level uint16
+
+// Rate is the sales tax rate one jurisdiction levies on sales shipped to a ZIP code or jurisdiction code
+type Rate struct {
+	Key   string
+	Level Level
+	Name  string
+	Rate  utils.Percentage
+}
+
+// Resolver finds the stacked sales tax rates of all jurisdictions for a destination from a local rate table
+type Resolver struct {
+	rates map[string][]Rate
+}
+
+// NewResolver constructor function for jurisdiction tax resolvers. Rates of a destination are ordered by their level
+func NewResolver(rates ...Rate) *Resolver {
+	r := &Resolver{
+		rates: map[string][]Rate{},
+	}
+
+	for _, rate := range rates {
+		key := normalizeKey(rate.Key)
+		r.rates[key] = append(r.rates[key], rate)
+	}
+
+	for _, rates := range r.rates {
+		sort.SliceStable(rates, func(i, j int) bool { return rates[i].Level < rates[j].Level })
+	}
+
+	return r
+}
+
+// LoadResolver reads a jurisdiction rate table from a CSV file with the columns key,level,name,rate
+// (e.g. 10001,city,New York City,4.5), where the key is a ZIP code or a jurisdiction code. A header row is skipped
+func LoadResolver(path string) (*Resolver, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadResolver(f)
+}
+
+// ReadResolver reads a jurisdiction rate table in the CSV format described by LoadResolver
+func ReadResolver(r io.Reader) (*Resolver, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []Rate
+	for i, record := range records {
+		// skip the header row
+		if i == 0 && strings.EqualFold(record[0], "key") {
+			continue
+		}
+
+		level, err := ParseLevel(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rate, err := utils.ParsePercentage(record[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rates = append(rates, Rate{
+			Key:   record[0],
+			Level: level,
+			Name:  record[2],
+			Rate:  rate,
+		})
+	}
+
+	return NewResolver(rates...), nil
+}
+
+// Resolve returns the rates of all jurisdictions for a destination ZIP code or jurisdiction code, ordered by their level.
+// ZIP+4 codes (e.g. 10001-1234) are resolved by their 5 digit ZIP code if they're not in the table
+func (r *Resolver) Resolve(destination string) ([]Rate, error) {
+	key := normalizeKey(destination)
+	if rates, ok := r.rates[key]; ok {
+		return rates, nil
+	}
+
+	if zip, _, found := strings.Cut(key, "-"); found {
+		if rates, ok := r.rates[zip]; ok {
+			return rates, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrJurisdictionNotFound, destination)
+}
+
+// Taxes returns a simple tax for every jurisdiction of the destination, named after its level and jurisdiction
+func (r *Resolver) Taxes(destination string) ([]models.Tax, error) {
+	rates, err := r.Resolve(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	taxes := make([]models.Tax, len(rates))
+	for i, rate := range rates {
+		taxes[i] = *models.NewSimpleTax(fmt.Sprintf("%v Tax (%v)", rate.Level, rate.Name), rate.Rate)
+	}
+
+	return taxes, nil
+}
+
+// ParseLevel parses a jurisdiction level from its name, e.g. "county"
+func ParseLevel(level string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "state":
+		return LevelState, nil
+	case "county":
+		return LevelCounty, nil
+	case "city":
+		return LevelCity, nil
+	case "special", "district":
+		return LevelSpecial, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, level)
+	}
+}
+
+// String represents a jurisdiction level as string for printing purposes
+func (l Level) String() string {
+	switch l {
+	case LevelState:
+		return "State"
+	case LevelCounty:
+		return "County"
+	case LevelCity:
+		return "City"
+	case LevelSpecial:
+		return "Special District"
+	default:
+		return "Unknown"
+	}
+}
+
+// normalizeKey makes ZIP codes and jurisdiction codes case and whitespace insensitive
+func normalizeKey(key string) string {
+	return strings.ToUpper(strings.TrimSpace(key))
+}
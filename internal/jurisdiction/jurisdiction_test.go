@@ -0,0 +1,102 @@
+package jurisdiction
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRates = `key,level,name,rate
+10001,special,Metropolitan Commuter Transportation District,0.375
+10001,state,New York,4
+10001,city,New York City,4.5
+NY-ERIE,state,New York,4
+NY-ERIE,county,Erie,4.75
+`
+
+func TestResolve(t *testing.T) {
+	resolver, _ := ReadResolver(strings.NewReader(testRates))
+
+	t.Run("RESOLVE_ZIP_ORDERED_BY_LEVEL", func(t *testing.T) {
+		// Arrange
+		expectedLevels := []Level{LevelState, LevelCity, LevelSpecial}
+		expectedRate, _ := utils.ParsePercentage("0.375")
+
+		// Act
+		res, err := resolver.Resolve("10001")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+		for i, level := range expectedLevels {
+			assert.Equal(t, level, res[i].Level)
+		}
+		assert.Equal(t, expectedRate, res[2].Rate)
+	})
+
+	t.Run("RESOLVE_ZIP_PLUS_FOUR", func(t *testing.T) {
+		// Act
+		res, err := resolver.Resolve("10001-1234")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+	})
+
+	t.Run("RESOLVE_JURISDICTION_CODE", func(t *testing.T) {
+		// Act
+		res, err := resolver.Resolve("ny-erie")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "Erie", res[1].Name)
+	})
+
+	t.Run("RESOLVE_NOT_FOUND", func(t *testing.T) {
+		// Act
+		_, err := resolver.Resolve("99999")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrJurisdictionNotFound)
+	})
+}
+
+func TestTaxes(t *testing.T) {
+	resolver, _ := ReadResolver(strings.NewReader(testRates))
+
+	t.Run("TAXES_NAMED_BY_LEVEL", func(t *testing.T) {
+		// Arrange
+		expectedNames := []string{"State Tax (New York)", "County Tax (Erie)"}
+
+		// Act
+		res, err := resolver.Taxes("NY-ERIE")
+
+		// Assert
+		assert.NoError(t, err)
+		for i, name := range expectedNames {
+			assert.Equal(t, name, res[i].Name())
+			assert.False(t, res[i].IsCompound())
+		}
+	})
+}
+
+func TestReadResolver(t *testing.T) {
+	t.Run("READ_RESOLVER_INVALID_LEVEL", func(t *testing.T) {
+		// Act
+		_, err := ReadResolver(strings.NewReader("10001,province,New York,4\n"))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidLevel)
+	})
+
+	t.Run("READ_RESOLVER_INVALID_RATE", func(t *testing.T) {
+		// Act
+		_, err := ReadResolver(strings.NewReader("10001,state,New York,104\n"))
+
+		// Assert
+		assert.ErrorIs(t, err, utils.ErrInvalidPercentage)
+	})
+}
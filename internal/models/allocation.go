@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+)
+
+// ErrInvalidRatios is returned when money can not be allocated with the given ratios
+var ErrInvalidRatios = errors.New("invalid allocation ratios")
+
+// Allocate splits the money amount into parts proportional to the ratios, e.g. Allocate(1, 1, 2) splits it into quarters, quarters and halves.
+// Parts are calculated in the minor units of the currency (4 decimal precision if the amount has more decimals than its currency)
+// and the remainder is distributed one unit at a time to the parts with the largest rounding loss, earlier parts first on ties,
+// so the parts always sum up to the original amount
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrInvalidRatios
+		}
+		total += r
+	}
+
+	if total == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	// the smallest unit the amount is split into
+	unit := format.Pow10(Precision - m.Currency.MinorUnits())
+	if m.Amount%unit != 0 {
+		unit = 1
+	}
+
+	// negative amounts are allocated as positive ones and negated afterwards
+	sign := int64(1)
+	if m.Amount < 0 {
+		sign = -1
+	}
+	units := m.Amount / unit * sign
+
+	parts := make([]Money, len(ratios))
+	losses := make([]int64, len(ratios))
+	remainder := units
+	for i, r := range ratios {
+		// the share is calculated without overflowing and truncated if it was rounded up. The loss is
+		// smaller than the total, so subtracting the products gives the exact loss even when they wrap around
+		share := format.MulDivRound(units, r, total)
+		losses[i] = units*r - share*total
+		if losses[i] < 0 {
+			share--
+			losses[i] += total
+		}
+
+		parts[i] = Money{Currency: m.Currency, Amount: share}
+		remainder -= share
+	}
+
+	// give the remaining units to the parts which lost the most when their share was truncated
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return losses[order[a]] > losses[order[b]]
+	})
+
+	for i := int64(0); i < remainder; i++ {
+		parts[order[i]].Amount++
+	}
+
+	for i := range parts {
+		parts[i].Amount *= unit * sign
+	}
+
+	return parts, nil
+}
+
+// Split splits the money amount into n equal parts, the remainder is distributed one unit at a time starting with the first part
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return m.Allocate(ratios...)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+)
+
+// CashRounding is a policy that rounds totals paid in cash to a fixed increment, e.g. 0.05 CHF
+type CashRounding struct {
+	// Increment with 4 decimal precision, zero uses the cash increment of the total's currency
+	Increment int64
+	Mode      format.RoundingMode
+}
+
+// NewCashRounding constructor function for store level cash rounding to a fixed increment, e.g. "0.05" or "1".
+// Invalid or non-positive increments use the cash increment of the currency instead
+func NewCashRounding(increment string, mode format.RoundingMode) CashRounding {
+	value, err := format.ParseDecimal(increment, Precision)
+	if err != nil || value < 0 {
+		value = 0
+	}
+
+	return CashRounding{
+		Increment: value,
+		Mode:      validRoundingMode(mode),
+	}
+}
+
+// NewCurrencyCashRounding constructor function for currency level cash rounding, which uses the cash increment of the total's currency
+func NewCurrencyCashRounding(mode format.RoundingMode) CashRounding {
+	return CashRounding{Mode: validRoundingMode(mode)}
+}
+
+// Apply rounds the total to the cash increment and returns the rounded total and the adjustment that was made
+func (r CashRounding) Apply(total Money) (rounded Money, adjustment Money) {
+	increment := r.increment(total.Currency)
+
+	rounded = Money{
+		Currency: total.Currency,
+		Amount:   format.DivRoundMode(total.Amount, increment, r.Mode) * increment,
+	}
+	adjustment = Money{
+		Currency: total.Currency,
+		Amount:   rounded.Amount - total.Amount,
+	}
+
+	return rounded, adjustment
+}
+
+// increment returns the cash increment with 4 decimal precision for the currency
+func (r CashRounding) increment(code currency.CurrencyCode) int64 {
+	if r.Increment > 0 {
+		return r.Increment
+	}
+
+	minorUnits := code.MinorUnits()
+	if minorUnits > Precision {
+		minorUnits = Precision
+	}
+	return code.CashIncrement() * format.Pow10(Precision-minorUnits)
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+)
+
+// Tax categories for products that are taxed with different rates
+const (
+	CategoryStandard TaxCategory = "standard"
+	CategoryReduced  TaxCategory = "reduced"
+	CategoryBooks    TaxCategory = "books"
+	CategoryFood     TaxCategory = "food"
+	CategoryDigital  TaxCategory = "digital"
+	CategoryZero     TaxCategory = "zero"
+)
+
+// ErrInvalidTaxRates is returned when a tax rate table can not be parsed
+var ErrInvalidTaxRates = errors.New("invalid tax rates")
+
+// ErrMissingDefaultRate is returned when a tax rate table has no rate for its default category
+var ErrMissingDefaultRate = errors.New("missing tax rate of the default category")
+
+// TaxCategory is the category a product is taxed by, e.g. books or food
+type TaxCategory string
+
+// TaxRates is a table of tax rates by tax category.
+// Products without a category, or with a category that's not in the table, are taxed with the rate of the default category
+type TaxRates struct {
+	rates           map[TaxCategory]utils.Percentage
+	defaultCategory TaxCategory
+}
+
+// NewTaxRates constructor function for tax rate tables. Rates outside of 0 to 100 percent are limited to that range.
+// Returns an error if the table has no rate for the default category, as products without a known category would go untaxed
+func NewTaxRates(defaultCategory TaxCategory, rates map[TaxCategory]utils.Percentage) (*TaxRates, error) {
+	table := &TaxRates{
+		rates:           make(map[TaxCategory]utils.Percentage, len(rates)),
+		defaultCategory: normalizeCategory(defaultCategory),
+	}
+
+	for category, rate := range rates {
+		table.rates[normalizeCategory(category)] = rate.Clamp()
+	}
+
+	if _, ok := table.rates[table.defaultCategory]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrMissingDefaultRate, defaultCategory)
+	}
+
+	return table, nil
+}
+
+// ParseTaxRates parses a tax rate table written as comma separated category=rate pairs, e.g. "books=5,food=0,digital=20.5"
+func ParseTaxRates(table string) (map[TaxCategory]utils.Percentage, error) {
+	rates := map[TaxCategory]utils.Percentage{}
+
+	for _, entry := range strings.Split(table, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		category, rate, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(category) == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTaxRates, entry)
+		}
+
+		value, err := utils.ParsePercentage(strings.TrimSpace(rate))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidTaxRates, entry, err)
+		}
+
+		rates[normalizeCategory(TaxCategory(category))] = value
+	}
+
+	return rates, nil
+}
+
+// Rate returns the tax rate of the category, or the rate of the default category if the category is not in the table
+func (t *TaxRates) Rate(category TaxCategory) utils.Percentage {
+	if rate, ok := t.rates[normalizeCategory(category)]; ok {
+		return rate
+	}
+	return t.rates[t.defaultCategory]
+}
+
+// DefaultCategory returns the category used for products without a known category
+func (t *TaxRates) DefaultCategory() TaxCategory {
+	return t.defaultCategory
+}
+
+// normalizeCategory makes categories case insensitive
+func normalizeCategory(category TaxCategory) TaxCategory {
+	return TaxCategory(strings.ToLower(strings.TrimSpace(string(category))))
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxRates(t *testing.T) {
+	rates, _ := NewTaxRates(CategoryStandard, map[TaxCategory]utils.Percentage{
+		CategoryStandard: utils.WholePercentage(20),
+		CategoryBooks:    utils.WholePercentage(5),
+		CategoryFood:     utils.WholePercentage(0),
+		CategoryDigital:  utils.WholePercentage(120),
+	})
+
+	t.Run("TAX_RATES_CATEGORY", func(t *testing.T) {
+		// Arrange
+		expectedResult := utils.WholePercentage(5)
+
+		// Act
+		res := rates.Rate(CategoryBooks)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TAX_RATES_ZERO_RATE", func(t *testing.T) {
+		// Arrange
+		expectedResult := utils.WholePercentage(0)
+
+		// Act
+		res := rates.Rate(CategoryFood)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TAX_RATES_DEFAULT_CATEGORY", func(t *testing.T) {
+		// Arrange
+		expectedResult := utils.WholePercentage(20)
+
+		// Act
+		resEmpty := rates.Rate("")
+		resUnknown := rates.Rate("clothing")
+
+		// Assert
+		assert.Equal(t, expectedResult, resEmpty)
+		assert.Equal(t, expectedResult, resUnknown)
+	})
+
+	t.Run("TAX_RATES_RATE_TOO_HIGH", func(t *testing.T) {
+		// Arrange
+		expectedResult := utils.WholePercentage(100)
+
+		// Act
+		res := rates.Rate(CategoryDigital)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TAX_RATES_MISSING_DEFAULT_RATE", func(t *testing.T) {
+		// Act
+		res, err := NewTaxRates(CategoryStandard, map[TaxCategory]utils.Percentage{
+			CategoryBooks: utils.WholePercentage(5),
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrMissingDefaultRate)
+		assert.Nil(t, res)
+	})
+}
+
+func TestParseTaxRates(t *testing.T) {
+	t.Run("PARSE_TAX_RATES_DEFAULT", func(t *testing.T) {
+		// Arrange
+		expectedResult := map[TaxCategory]utils.Percentage{CategoryBooks: utils.WholePercentage(5), CategoryFood: utils.WholePercentage(0), CategoryDigital: 205000}
+
+		// Act
+		res, err := ParseTaxRates("Books=5, food=0,digital = 20.5")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("PARSE_TAX_RATES_EMPTY", func(t *testing.T) {
+		// Act
+		res, err := ParseTaxRates("")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("PARSE_TAX_RATES_INVALID", func(t *testing.T) {
+		// Act
+		_, errMissingRate := ParseTaxRates("books")
+		_, errInvalidRate := ParseTaxRates("books=five")
+		_, errRateTooHigh := ParseTaxRates("books=101")
+
+		// Assert
+		assert.ErrorIs(t, errMissingRate, ErrInvalidTaxRates)
+		assert.ErrorIs(t, errInvalidRate, ErrInvalidTaxRates)
+		assert.ErrorIs(t, errRateTooHigh, ErrInvalidTaxRates)
+	})
+}
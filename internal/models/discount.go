@@ -1,5 +1,18 @@
 package models
 
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+)
+
 // Enum for the types of discount precedence
 const (
 	NoPrecedence TakesPrecedence = iota
@@ -7,79 +20,297 @@ const (
 	PrecedenceSpecial
 )
 
-// Enum to indicate if a discount takes precedence over tax
+// ErrInvalidDiscount is returned when a discount rule can not be parsed
+var ErrInvalidDiscount = errors.New("invalid discount")
+
+// Enum to indicate if a discount takes precedence over tax.
+// It can move only one of a universal and a special discount before tax, each discount rule declares its own precedence with WithBeforeTax
 type TakesPrecedence uint16
 
-// Discount contains the 2 different types of discounts
+// Discount contains an ordered collection of discount rules. Rules that apply before tax are calculated first, in order,
+// then the rules that apply after tax are calculated on the price left after the before-tax discounts.
+// Fixed discounts are deducted from the price left after the rules before them, so percentages after them are calculated on less
 type Discount struct {
-	UniversalDiscount universalDiscount
-	SpecialDiscount   specialDiscount
-	TakesPrecedence   TakesPrecedence
+	Rules []DiscountRule
 }
 
-// Universal Discount that can apply to all products
-type universalDiscount struct {
-	rate   uint16
-	Amount Money
+// DiscountRule is a single discount with its own targeting, rate or fixed amount, and precedence over tax
+type DiscountRule struct {
+	name      string
+	rate      utils.Percentage
+	fixed     *Money
+	matcher   Matcher
+	beforeTax bool
+	window    *PromotionWindow
+	Amount    Money
 }
 
-// Special Discount that applies to products with specified UPC
-type specialDiscount struct {
-	upc    int
-	rate   uint16
-	Amount Money
-}
+// NewDiscountRule constructor function for discounts that apply to every product after tax. Rates outside of 0 to 100 percent are limited to that range
+func NewDiscountRule(name string, rate utils.Percentage) *DiscountRule {
+	if name == "" {
+		name = "Discount"
+	}
 
-// NewUniversalDiscount constructor function for universal discounts
-func NewUniversalDiscount(rate uint16, amount Money) *universalDiscount {
-	if rate > 100 {
-		rate = 100
+	return &DiscountRule{
+		name: name,
+		rate: rate.Clamp(),
 	}
+}
 
-	return &universalDiscount{
-		rate:   rate,
-		Amount: amount,
+// NewFixedDiscountRule constructor function for discounts of a fixed amount that apply to every product after tax, e.g. 2.00 off.
+// Negative amounts are set to 0
+func NewFixedDiscountRule(name string, amount Money) *DiscountRule {
+	if amount.Amount < 0 {
+		amount.Amount = 0
 	}
+
+	d := NewDiscountRule(name, 0)
+	d.fixed = &amount
+	return d
+}
+
+// NewUniversalDiscount constructor function for universal discounts that apply to all products. Rates outside of 0 to 100 percent are limited to that range
+func NewUniversalDiscount(rate utils.Percentage, amount Money) *DiscountRule {
+	d := NewDiscountRule("Universal discount", rate)
+	d.Amount = amount
+	return d
 }
 
-// NewSpecialDiscount constructor function for special discounts
-func NewSpecialDiscount(upc int, rate uint16, amount Money) *specialDiscount {
+// NewSpecialDiscount constructor function for special discounts that apply to products with the specified UPC.
+// Rates outside of 0 to 100 percent are limited to that range
+func NewSpecialDiscount(upc int, rate utils.Percentage, amount Money) *DiscountRule {
 	if upc < 0 {
 		upc = 0
 	}
 
-	if rate > 100 {
-		rate = 100
+	d := NewDiscountRule("Special discount", rate).WithUPCs(upc)
+	d.Amount = amount
+	return d
+}
+
+// NewDiscounts constructor function for an ordered collection of discount rules
+func NewDiscounts(rules ...DiscountRule) *Discount {
+	return &Discount{
+		Rules: rules,
 	}
+}
 
-	return &specialDiscount{
-		upc:    upc,
-		rate:   rate,
-		Amount: amount,
+// NewDiscount constructor function for a universal and a special discount. Both keep their own precedence over tax,
+// and the precedence can additionally move one of them before tax. The discount that takes precedence is calculated first
+func NewDiscount(universal DiscountRule, special DiscountRule, precedence TakesPrecedence) *Discount {
+	switch precedence {
+	case PrecedenceUniversal:
+		universal.beforeTax = true
+	case PrecedenceSpecial:
+		special.beforeTax = true
+		return NewDiscounts(special, universal)
 	}
+
+	return NewDiscounts(universal, special)
 }
 
-// NewDiscount constructor function for new discounts
-func NewDiscount(universal universalDiscount, special specialDiscount, precedence TakesPrecedence) *Discount {
+// LoadDiscountRules reads an ordered list of discount rules from a CSV file with the columns name,discount,target,tax
+// (e.g. Book week,5,upc:123456 654321;category:books,before), where the discount is a percentage or a fixed amount
+// followed by its currency (e.g. 2.00 EUR), the target is written as described by ParseMatcher and empty for every product,
+// and tax is "before" or "after". Promotions add the columns valid_from,valid_to,days,hours as described by ParsePromotionWindow,
+// in the time zone of the store. A header row is skipped
+func LoadDiscountRules(path string, location *time.Location) ([]DiscountRule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
 
-	return &Discount{
-		UniversalDiscount: universal,
-		SpecialDiscount:   special,
-		TakesPrecedence:   precedence,
+	return ReadDiscountRules(f, location)
+}
+
+// ReadDiscountRules reads discount rules in the CSV format described by LoadDiscountRules
+func ReadDiscountRules(r io.Reader, location *time.Location) ([]DiscountRule, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []DiscountRule
+	for i, record := range records {
+		// skip the header row
+		if i == 0 && strings.EqualFold(record[0], "name") {
+			continue
+		}
+
+		if len(record) != 4 && len(record) != 8 {
+			return nil, fmt.Errorf("line %d: %w: expected 4 or 8 columns, got %d", i+1, ErrInvalidDiscount, len(record))
+		}
+
+		rule, err := parseDiscountValue(record[0], record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		matcher, err := ParseMatcher(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		var beforeTax bool
+		switch strings.ToLower(strings.TrimSpace(record[3])) {
+		case "before":
+			beforeTax = true
+		case "after", "":
+		default:
+			return nil, fmt.Errorf("line %d: %w: tax %q is not before or after", i+1, ErrInvalidDiscount, record[3])
+		}
+
+		rule.WithMatcher(matcher).WithBeforeTax(beforeTax)
+
+		// rows without any of the promotion columns are always active
+		if len(record) == 8 && strings.TrimSpace(strings.Join(record[4:], "")) != "" {
+			window, err := ParsePromotionWindow(record[4], record[5], record[6], record[7], location)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			rule.WithWindow(window)
+		}
+
+		rules = append(rules, *rule)
 	}
+
+	return rules, nil
 }
 
-// Rate returns the discount rate for universal discounts
-func (d *universalDiscount) Rate() uint16 {
-	return d.rate
+// parseDiscountValue parses a discount that is either a percentage (e.g. 10) or a fixed amount followed by its currency (e.g. 2.00 EUR)
+func parseDiscountValue(name, value string) (*DiscountRule, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		rate, err := utils.ParsePercentage(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		return NewDiscountRule(name, rate), nil
+	}
+
+	code, err := currency.ParseCurrencyCode(fields[1])
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := ParseMoney(code, fields[0])
+	if err != nil || amount.Amount < 0 {
+		return nil, fmt.Errorf("%w: amount %q", ErrInvalidDiscount, value)
+	}
+
+	return NewFixedDiscountRule(name, amount), nil
+}
+
+// WithMatcher returns the discount limited to the products the matcher matches, a nil matcher matches every product
+func (d *DiscountRule) WithMatcher(matcher Matcher) *DiscountRule {
+	d.matcher = matcher
+	return d
+}
+
+// WithUPCs returns the discount limited to products with one of the UPCs
+func (d *DiscountRule) WithUPCs(upcs ...int) *DiscountRule {
+	return d.WithMatcher(MatchUPCs(upcs...))
+}
+
+// WithBeforeTax sets if the discount is deducted from the price before it gets taxed. Discounts apply after tax by default
+func (d *DiscountRule) WithBeforeTax(beforeTax bool) *DiscountRule {
+	d.beforeTax = beforeTax
+	return d
+}
+
+// WithWindow returns the discount limited to the time the promotion is active in
+func (d *DiscountRule) WithWindow(window PromotionWindow) *DiscountRule {
+	d.window = &window
+	return d
+}
+
+// Active checks if the discount is active at the given time. Discounts without a promotion window are always active
+func (d *DiscountRule) Active(at time.Time) bool {
+	return d.window == nil || d.window.Active(at)
+}
+
+// Applies checks if the discount applies to the product. Discounts without a matcher apply to every product
+func (d *DiscountRule) Applies(p *Product) bool {
+	return d.matcher == nil || d.matcher.Matches(p)
+}
+
+// Calculate returns the amount of the discount on the price it applies to. Fixed discounts never exceed the price and are credited
+// if the price is a credit. Returns an error if a fixed discount is not in the currency of the price
+func (d *DiscountRule) Calculate(price Money) (Money, error) {
+	if d.fixed == nil {
+		return price.Percent(d.rate), nil
+	}
+
+	if d.fixed.Currency != price.Currency {
+		return Money{Currency: price.Currency}, fmt.Errorf("%w: discount %v is in %v, price is in %v", ErrCurrencyMismatch, d.name, d.fixed.Currency, price.Currency)
+	}
+
+	amount := *d.fixed
+	if amount.Amount > price.Abs().Amount {
+		amount = price.Abs()
+	}
+
+	if price.Amount < 0 {
+		return amount.Neg(), nil
+	}
+	return amount, nil
+}
+
+// Applicable returns the rules that are active at the given time and apply to the product in order,
+// split into the rules that apply before and after tax
+func (d *Discount) Applicable(p *Product, at time.Time) (beforeTax, afterTax []*DiscountRule) {
+	for i := range d.Rules {
+		rule := &d.Rules[i]
+		if !rule.Active(at) || !rule.Applies(p) {
+			continue
+		}
+
+		if rule.beforeTax {
+			beforeTax = append(beforeTax, rule)
+		} else {
+			afterTax = append(afterTax, rule)
+		}
+	}
+	return beforeTax, afterTax
+}
+
+// Name returns the name of the discount
+func (d *DiscountRule) Name() string {
+	return d.name
 }
 
-// Rate returns the discount rate for special discounts
-func (d *specialDiscount) Rate() uint16 {
+// Rate returns the discount rate, 0 for fixed discounts
+func (d *DiscountRule) Rate() utils.Percentage {
 	return d.rate
 }
 
-// UPC returns a special discount's UPC
-func (s *specialDiscount) UPC() int {
-	return s.upc
+// FixedAmount returns the amount of a fixed discount, false if the discount is a percentage
+func (d *DiscountRule) FixedAmount() (Money, bool) {
+	if d.fixed == nil {
+		return Money{}, false
+	}
+	return *d.fixed, true
+}
+
+// Matcher returns the matcher of the products the discount applies to, nil if it applies to every product
+func (d *DiscountRule) Matcher() Matcher {
+	return d.matcher
+}
+
+// Window returns the time the promotion is active in, false if the discount is always active
+func (d *DiscountRule) Window() (PromotionWindow, bool) {
+	if d.window == nil {
+		return PromotionWindow{}, false
+	}
+	return *d.window, true
+}
+
+// IsBeforeTax returns true if the discount is deducted from the price before it gets taxed
+func (d *DiscountRule) IsBeforeTax() bool {
+	return d.beforeTax
 }
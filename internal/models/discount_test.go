@@ -0,0 +1,148 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountRules(t *testing.T) {
+	t.Run("DISCOUNT_PRECEDENCE_ORDER", func(t *testing.T) {
+		// Arrange
+		universal := *NewUniversalDiscount(utils.WholePercentage(15), Money{})
+		special := *NewSpecialDiscount(123456, utils.WholePercentage(7), Money{})
+
+		// Act
+		res := NewDiscount(universal, special, PrecedenceSpecial)
+
+		// Assert
+		assert.Equal(t, "Special discount", res.Rules[0].Name())
+		assert.True(t, res.Rules[0].IsBeforeTax())
+		assert.Equal(t, "Universal discount", res.Rules[1].Name())
+		assert.False(t, res.Rules[1].IsBeforeTax())
+	})
+
+	t.Run("DISCOUNT_APPLICABLE", func(t *testing.T) {
+		// Arrange
+		p := NewProduct("The Little Prince", 123456, NewMoney(currency.USD, "20.25"), NewCosts())
+		discount := NewDiscounts(
+			*NewDiscountRule("Autumn sale", utils.WholePercentage(10)),
+			*NewDiscountRule("Clearance", utils.WholePercentage(20)).WithUPCs(999999),
+			*NewDiscountRule("Book week", utils.WholePercentage(5)).WithUPCs(654321, 123456).WithBeforeTax(true),
+		)
+
+		// Act
+		beforeTax, afterTax := discount.Applicable(&p, time.Date(2026, 11, 23, 12, 0, 0, 0, time.UTC))
+
+		// Assert
+		assert.Len(t, beforeTax, 1)
+		assert.Equal(t, "Book week", beforeTax[0].Name())
+		assert.Len(t, afterTax, 1)
+		assert.Equal(t, "Autumn sale", afterTax[0].Name())
+	})
+
+	t.Run("DISCOUNT_APPLICABLE_ACTIVE_PROMOTIONS", func(t *testing.T) {
+		// Arrange
+		p := NewProduct("The Little Prince", 123456, NewMoney(currency.USD, "20.25"), NewCosts())
+		saleEnds := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+		discount := NewDiscounts(
+			*NewDiscountRule("Black Friday", utils.WholePercentage(25)).WithWindow(PromotionWindow{ValidTo: saleEnds}),
+			*NewDiscountRule("Happy hour", utils.WholePercentage(15)).WithWindow(PromotionWindow{DailyFrom: 17 * time.Hour, DailyTo: 19 * time.Hour}),
+		)
+
+		// Act
+		_, duringSale := discount.Applicable(&p, saleEnds.Add(-time.Hour))
+		_, afterSale := discount.Applicable(&p, saleEnds.Add(17*time.Hour))
+
+		// Assert
+		assert.Len(t, duringSale, 1)
+		assert.Equal(t, "Black Friday", duringSale[0].Name())
+		assert.Len(t, afterSale, 1)
+		assert.Equal(t, "Happy hour", afterSale[0].Name())
+	})
+
+	t.Run("DISCOUNT_READ_RULES", func(t *testing.T) {
+		// Arrange
+		csv := "name,rate,target,tax\nAutumn sale,10,,after\nBook week,5,upc:123456 654321;category:books,before\n"
+		p := NewProduct("The Little Prince", 999999, NewMoney(currency.USD, "20.25"), NewCosts()).WithTaxCategory(CategoryBooks)
+		other := NewProduct("The Hobbit", 888888, NewMoney(currency.USD, "20.25"), NewCosts())
+
+		// Act
+		res, err := ReadDiscountRules(strings.NewReader(csv), time.UTC)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Nil(t, res[0].Matcher())
+		assert.True(t, res[1].Applies(&p))
+		assert.False(t, res[1].Applies(&other))
+		assert.Equal(t, utils.WholePercentage(5), res[1].Rate())
+		assert.True(t, res[1].IsBeforeTax())
+	})
+
+	t.Run("DISCOUNT_READ_RULES_INVALID", func(t *testing.T) {
+		// Arrange
+		csv := "Book week,5,upc:123456,during\n"
+
+		// Act
+		_, err := ReadDiscountRules(strings.NewReader(csv), time.UTC)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidDiscount)
+	})
+	t.Run("DISCOUNT_READ_PROMOTIONS", func(t *testing.T) {
+		// Arrange
+		csv := "name,discount,target,tax,valid_from,valid_to,days,hours\nAutumn sale,10,,after,,,,\nHappy hour,15,,after,2026-11-01,,fri,17:00-19:00\n"
+		location := time.FixedZone("EST", -5*60*60)
+
+		// Act
+		res, err := ReadDiscountRules(strings.NewReader(csv), location)
+		_, always := res[0].Window()
+		window, limited := res[1].Window()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, always)
+		assert.True(t, limited)
+		assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, location), window.ValidFrom)
+		assert.True(t, res[1].Active(time.Date(2026, 11, 27, 22, 30, 0, 0, time.UTC)))
+		assert.False(t, res[1].Active(time.Date(2026, 11, 27, 17, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("DISCOUNT_READ_FIXED_RULES", func(t *testing.T) {
+		// Arrange
+		csv := "name,discount,target,tax\n2.00 off,2.00 USD,,after\n"
+
+		expectedAmount := NewMoney(currency.USD, "2.00")
+
+		// Act
+		res, err := ReadDiscountRules(strings.NewReader(csv), time.UTC)
+		amount, fixed := res[0].FixedAmount()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, fixed)
+		assert.Equal(t, expectedAmount, amount)
+	})
+
+	t.Run("DISCOUNT_FIXED_NEVER_EXCEEDS_PRICE", func(t *testing.T) {
+		// Arrange
+		rule := NewFixedDiscountRule("25.00 off", NewMoney(currency.USD, "25.00"))
+		price := NewMoney(currency.USD, "20.25")
+
+		// Act
+		res, err := rule.Calculate(price)
+		credit, errCredit := rule.Calculate(price.Neg())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, errCredit)
+		assert.Equal(t, price, res)
+		assert.Equal(t, price.Neg(), credit)
+	})
+}
@@ -2,27 +2,41 @@ package models
 
 import (
 	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
 
 	"fmt"
 )
 
 // Expense interface defines behaviour for all Expense types that implement it
 type expense interface {
-	CalculateExpense(amount Money) Money
-	ReportExpense(startingPrice Money)
+	CalculateExpense(amount Money) (Money, error)
+	ExpenseLines(startingPrice Money) ([]ExpenseLine, error)
+}
+
+// ExpenseLine is a single calculated expense with its description, used for reporting each cost separately.
+// Taxable expenses are part of the tax base and discountable expenses are part of the discount base
+type ExpenseLine struct {
+	Description  string
+	Amount       Money
+	Taxable      bool
+	Discountable bool
 }
 
 // expensePercentage represents percentage-based expenses
 type expensePercentage struct {
-	Description string
-	Amount      float64
-	Currency    currency.CurrencyCode
+	Description  string
+	Amount       utils.Percentage
+	Currency     currency.CurrencyCode
+	taxable      bool
+	discountable bool
 }
 
 // expenseAbsolute represents expenses with absolute values
 type expenseAbsolute struct {
-	Description string
-	Amount      Money
+	Description  string
+	Amount       Money
+	taxable      bool
+	discountable bool
 }
 
 // Costs represents all of the expenses for a product
@@ -40,88 +54,115 @@ func NewCosts(e ...expense) Costs {
 	}
 }
 
-// NewExpensePercentage constructor for percentage based expenses
-func NewExpensePercentage(description string, amount float64) *expensePercentage {
-	if amount < 0 {
-		amount = 0
-	}
-
+// NewExpensePercentage constructor for percentage based expenses. Percentages outside of 0 to 100 are limited to that range
+func NewExpensePercentage(description string, amount utils.Percentage) *expensePercentage {
 	return &expensePercentage{
 		Description: description,
-		Amount:      amount,
+		Amount:      amount.Clamp(),
 	}
 }
 
-// NewExpenseAbsolute constructor for absolute value expenses
-func NewExpenseAbsolute(description string, amount float64) *expenseAbsolute {
-	if amount < 0 {
-		amount = 0
+// NewExpenseAbsolute constructor for absolute value expenses, negative amounts are set to 0
+func NewExpenseAbsolute(description string, amount Money) *expenseAbsolute {
+	if amount.Amount < 0 {
+		amount.Amount = 0
 	}
 
 	return &expenseAbsolute{
 		Description: description,
-		Amount: Money{
-			Value: amount,
-		},
+		Amount:      amount,
 	}
 }
 
-// CalculateExpense calculates the exact amount of expense from a percentage
-func (e *expensePercentage) CalculateExpense(startingPrice Money) Money {
+// WithTaxable sets if the expense is taxed together with the product, e.g. taxable shipping. Expenses are not taxed by default
+func (e *expensePercentage) WithTaxable(taxable bool) *expensePercentage {
+	e.taxable = taxable
+	return e
+}
 
-	expenseAmount := (e.Amount / 100) * startingPrice.Value
+// WithDiscountable sets if the product discounts also apply to the expense. Expenses are not discounted by default
+func (e *expensePercentage) WithDiscountable(discountable bool) *expensePercentage {
+	e.discountable = discountable
+	return e
+}
 
-	return Money{
-		Value:    expenseAmount,
-		Currency: startingPrice.Currency,
-	}
+// WithTaxable sets if the expense is taxed together with the product, e.g. taxable shipping. Expenses are not taxed by default
+func (e *expenseAbsolute) WithTaxable(taxable bool) *expenseAbsolute {
+	e.taxable = taxable
+	return e
 }
 
-// CalculateExpense for absolute value expenses returns the amount of expense for absolute amount expenses
-func (e *expenseAbsolute) CalculateExpense(startingPrice Money) Money {
-	return Money{
-		Value:    e.Amount.Value,
-		Currency: startingPrice.Currency,
+// WithDiscountable sets if the product discounts also apply to the expense. Expenses are not discounted by default
+func (e *expenseAbsolute) WithDiscountable(discountable bool) *expenseAbsolute {
+	e.discountable = discountable
+	return e
+}
+
+// CalculateExpense calculates the exact amount of expense from a percentage
+func (e *expensePercentage) CalculateExpense(startingPrice Money) (Money, error) {
+	return startingPrice.Percent(e.Amount), nil
+}
+
+// CalculateExpense for absolute value expenses returns the amount of expense for absolute amount expenses.
+// Returns an error if the expense is not in the currency of the starting price
+func (e *expenseAbsolute) CalculateExpense(startingPrice Money) (Money, error) {
+	if e.Amount.Currency != startingPrice.Currency {
+		return Money{Currency: startingPrice.Currency}, fmt.Errorf("%w: expense %v is in %v, price is in %v", ErrCurrencyMismatch, e.Description, e.Amount.Currency, startingPrice.Currency)
 	}
+
+	return e.Amount, nil
 }
 
 // CalculateExpense iterates through a list of costs, calculates their expenses and returns the sum of costs
-func (e Costs) CalculateExpense(startingPrice Money) Money {
-	var sum float64 = 0
+func (e Costs) CalculateExpense(startingPrice Money) (Money, error) {
+	sum := Money{Currency: startingPrice.Currency}
 	for _, v := range e.Expenses {
-		sum += v.CalculateExpense(startingPrice).Value
-	}
-	return Money{
-		Currency: startingPrice.Currency,
-		Value:    sum,
+		amount, err := v.CalculateExpense(startingPrice)
+		if err != nil {
+			return Money{Currency: startingPrice.Currency}, err
+		}
+
+		sum, err = sum.Add(amount)
+		if err != nil {
+			return Money{Currency: startingPrice.Currency}, err
+		}
 	}
+	return sum, nil
 }
 
-// ReportExpense iterates through all costs and reports them unless they're nil
-func (e Costs) ReportExpense(startingPrice Money) {
+// ExpenseLines iterates through all costs and returns their calculated lines
+func (e Costs) ExpenseLines(startingPrice Money) ([]ExpenseLine, error) {
+	var lines []ExpenseLine
 	for _, v := range e.Expenses {
-		v.ReportExpense(startingPrice)
+		if v == nil {
+			continue
+		}
+
+		l, err := v.ExpenseLines(startingPrice)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l...)
 	}
+	return lines, nil
 }
 
-// ToString method to report the value of absolute expense costs
-func (e *expenseAbsolute) ReportExpense(startingPrice Money) {
-
-	if e.Amount.Value != 0 {
-		str := fmt.Sprintf("%v =  %.2f %v", e.Description, e.Amount.Value, e.Amount.Currency)
-		fmt.Println(str)
+// ExpenseLines returns the calculated line of an absolute expense
+func (e *expenseAbsolute) ExpenseLines(startingPrice Money) ([]ExpenseLine, error) {
+	amount, err := e.CalculateExpense(startingPrice)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// ToString method to calculate and report the value of percentage expense costs
-func (e *expensePercentage) ReportExpense(startingPrice Money) {
-
-	amount := e.CalculateExpense(startingPrice)
-	if amount.Value != 0 {
-		formattedAmount := fmt.Sprintf("%.2f %v", amount.Value, amount.Currency.String())
-		str := fmt.Sprintf("%v = %v", e.Description, formattedAmount)
+	return []ExpenseLine{{Description: e.Description, Amount: amount, Taxable: e.taxable, Discountable: e.discountable}}, nil
+}
 
-		fmt.Println(str)
+// ExpenseLines returns the calculated line of a percentage expense
+func (e *expensePercentage) ExpenseLines(startingPrice Money) ([]ExpenseLine, error) {
+	amount, err := e.CalculateExpense(startingPrice)
+	if err != nil {
+		return nil, err
 	}
 
+	return []ExpenseLine{{Description: e.Description, Amount: amount, Taxable: e.taxable, Discountable: e.discountable}}, nil
 }
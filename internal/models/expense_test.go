@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpensePercentage(t *testing.T) {
+	t.Run("EXPENSE_FRACTIONAL_PERCENTAGE", func(t *testing.T) {
+		// Arrange
+		price := NewMoney(currency.USD, "20.25")
+		rate, _ := utils.ParsePercentage("2.5")
+		expense := NewExpensePercentage("Packaging", rate)
+
+		// 20.25 * 2.5% = 0.50625
+		var expectedResult int64 = 5063
+
+		// Act
+		res, err := expense.CalculateExpense(price)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res.Amount)
+	})
+
+	t.Run("EXPENSE_PERCENTAGE_OUT_OF_RANGE", func(t *testing.T) {
+		// Arrange
+		price := NewMoney(currency.USD, "20.25")
+
+		// Act
+		negative, _ := NewExpensePercentage("Packaging", -utils.WholePercentage(5)).CalculateExpense(price)
+		tooLarge, _ := NewExpensePercentage("Packaging", utils.WholePercentage(150)).CalculateExpense(price)
+
+		// Assert
+		assert.Equal(t, int64(0), negative.Amount)
+		assert.Equal(t, price.Amount, tooLarge.Amount)
+	})
+}
@@ -0,0 +1,227 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// upcDigits is the amount of digits of a UPC-A, shorter UPCs are compared with leading zeros
+const upcDigits = 12
+
+// ErrInvalidMatcher is returned when a product matcher can not be parsed
+var ErrInvalidMatcher = errors.New("invalid product matcher")
+
+// Matcher decides which products a discount targets
+type Matcher interface {
+	Matches(p *Product) bool
+}
+
+// upcMatcher matches products with one of the listed UPCs
+type upcMatcher struct {
+	upcs []int
+}
+
+// upcRangeMatcher matches products with a UPC in an inclusive range
+type upcRangeMatcher struct {
+	from int
+	to   int
+}
+
+// upcPrefixMatcher matches products whose UPC starts with one of the prefixes, e.g. a manufacturer code
+type upcPrefixMatcher struct {
+	prefixes []string
+}
+
+// categoryMatcher matches products in one of the tax categories
+type categoryMatcher struct {
+	categories []TaxCategory
+}
+
+// tagMatcher matches products with at least one of the tags
+type tagMatcher struct {
+	tags []string
+}
+
+// anyMatcher matches products that match at least one of its matchers
+type anyMatcher struct {
+	matchers []Matcher
+}
+
+// MatchUPCs returns a matcher for products with one of the UPCs
+func MatchUPCs(upcs ...int) Matcher {
+	return &upcMatcher{upcs: upcs}
+}
+
+// MatchUPCRange returns a matcher for products with a UPC from one UPC to another, inclusive. The bounds are swapped if they're reversed
+func MatchUPCRange(from, to int) Matcher {
+	if from > to {
+		from, to = to, from
+	}
+	return &upcRangeMatcher{from: from, to: to}
+}
+
+// MatchUPCPrefixes returns a matcher for products whose UPC starts with one of the prefixes, e.g. "0123" for a manufacturer code.
+// UPCs are compared as 12 digit UPC-A codes, so UPCs with leading zeros match prefixes starting with 0
+func MatchUPCPrefixes(prefixes ...string) Matcher {
+	return &upcPrefixMatcher{prefixes: prefixes}
+}
+
+// MatchCategories returns a matcher for products in one of the tax categories, categories are case insensitive.
+// Products without a category are in the standard category
+func MatchCategories(categories ...TaxCategory) Matcher {
+	normalized := make([]TaxCategory, len(categories))
+	for i, c := range categories {
+		normalized[i] = normalizeCategory(c)
+	}
+	return &categoryMatcher{categories: normalized}
+}
+
+// MatchTags returns a matcher for products with at least one of the tags, tags are case insensitive
+func MatchTags(tags ...string) Matcher {
+	normalized := make([]string, len(tags))
+	for i, t := range tags {
+		normalized[i] = normalizeTag(t)
+	}
+	return &tagMatcher{tags: normalized}
+}
+
+// MatchAny returns a matcher for products that match at least one of the matchers
+func MatchAny(matchers ...Matcher) Matcher {
+	return &anyMatcher{matchers: matchers}
+}
+
+// ParseMatcher parses a matcher written as semicolon separated kind:values terms, where the values are separated by spaces,
+// e.g. "upc:123456 654321;prefix:0123;range:100000-199999;category:books;tag:sale".
+// Products that match any of the terms are matched. Returns nil for an empty string, which matches every product
+func ParseMatcher(value string) (Matcher, error) {
+	var matchers []Matcher
+
+	for _, term := range strings.Split(value, ";") {
+		if strings.TrimSpace(term) == "" {
+			continue
+		}
+
+		kind, list, found := strings.Cut(term, ":")
+		values := strings.Fields(list)
+		if !found || len(values) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMatcher, term)
+		}
+
+		var m Matcher
+		switch strings.ToLower(strings.TrimSpace(kind)) {
+		case "upc":
+			upcs := make([]int, len(values))
+			for i, v := range values {
+				upc, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("%w: UPC %q", ErrInvalidMatcher, v)
+				}
+				upcs[i] = upc
+			}
+			m = MatchUPCs(upcs...)
+		case "range":
+			from, to, found := strings.Cut(list, "-")
+			fromUPC, errFrom := strconv.Atoi(strings.TrimSpace(from))
+			toUPC, errTo := strconv.Atoi(strings.TrimSpace(to))
+			if !found || errFrom != nil || errTo != nil {
+				return nil, fmt.Errorf("%w: UPC range %q", ErrInvalidMatcher, list)
+			}
+			m = MatchUPCRange(fromUPC, toUPC)
+		case "prefix":
+			for _, v := range values {
+				if _, err := strconv.Atoi(v); err != nil {
+					return nil, fmt.Errorf("%w: UPC prefix %q", ErrInvalidMatcher, v)
+				}
+			}
+			m = MatchUPCPrefixes(values...)
+		case "category":
+			categories := make([]TaxCategory, len(values))
+			for i, v := range values {
+				categories[i] = TaxCategory(v)
+			}
+			m = MatchCategories(categories...)
+		case "tag":
+			m = MatchTags(values...)
+		default:
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidMatcher, kind)
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	switch len(matchers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matchers[0], nil
+	default:
+		return MatchAny(matchers...), nil
+	}
+}
+
+// Matches checks if the product has one of the UPCs
+func (m *upcMatcher) Matches(p *Product) bool {
+	for _, upc := range m.upcs {
+		if upc == p.UPC() {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if the UPC of the product is in the range
+func (m *upcRangeMatcher) Matches(p *Product) bool {
+	return p.UPC() >= m.from && p.UPC() <= m.to
+}
+
+// Matches checks if the UPC of the product, padded with leading zeros to a UPC-A, starts with one of the prefixes
+func (m *upcPrefixMatcher) Matches(p *Product) bool {
+	upc := fmt.Sprintf("%0*d", upcDigits, p.UPC())
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(upc, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if the product is in one of the categories, products without a category are in the standard category
+func (m *categoryMatcher) Matches(p *Product) bool {
+	category := normalizeCategory(p.TaxCategory())
+	if category == "" {
+		category = CategoryStandard
+	}
+	for _, c := range m.categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if the product has at least one of the tags
+func (m *tagMatcher) Matches(p *Product) bool {
+	for _, tag := range m.tags {
+		if p.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if the product matches at least one of the matchers
+func (m *anyMatcher) Matches(p *Product) bool {
+	for _, matcher := range m.matchers {
+		if matcher.Matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTag makes tags case insensitive
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher(t *testing.T) {
+	p := NewProduct("The Little Prince", 123456, NewMoney(currency.USD, "20.25"), NewCosts()).
+		WithTaxCategory(CategoryBooks).
+		WithTags("Bestseller", "classic")
+
+	t.Run("MATCH_UPCS", func(t *testing.T) {
+		// Act
+		match := MatchUPCs(654321, 123456).Matches(&p)
+		noMatch := MatchUPCs(654321).Matches(&p)
+
+		// Assert
+		assert.True(t, match)
+		assert.False(t, noMatch)
+	})
+
+	t.Run("MATCH_UPC_RANGE_AND_PREFIX", func(t *testing.T) {
+		// Act
+		inRange := MatchUPCRange(123999, 123000).Matches(&p)
+		outOfRange := MatchUPCRange(100000, 123455).Matches(&p)
+		prefix := MatchUPCPrefixes("999", "000000123").Matches(&p)
+		noPrefix := MatchUPCPrefixes("0000001234567").Matches(&p)
+
+		// Assert
+		assert.True(t, inRange)
+		assert.False(t, outOfRange)
+		assert.True(t, prefix)
+		assert.False(t, noPrefix)
+	})
+
+	t.Run("MATCH_UPC_PREFIX_LEADING_ZERO", func(t *testing.T) {
+		// Arrange
+		leadingZero := NewProduct("The Hobbit", 12345678905, NewMoney(currency.USD, "20.25"), NewCosts())
+
+		// Act
+		prefix := MatchUPCPrefixes("0123").Matches(&leadingZero)
+		parsed, err := ParseMatcher("prefix:01234")
+		otherPrefix := MatchUPCPrefixes("123").Matches(&leadingZero)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, prefix)
+		assert.True(t, parsed.Matches(&leadingZero))
+		assert.False(t, otherPrefix)
+	})
+
+	t.Run("MATCH_CATEGORY_WITHOUT_CATEGORY", func(t *testing.T) {
+		// Arrange
+		uncategorized := NewProduct("The Hobbit", 654321, NewMoney(currency.USD, "20.25"), NewCosts())
+
+		// Act
+		standard := MatchCategories(CategoryStandard).Matches(&uncategorized)
+		books := MatchCategories(CategoryBooks).Matches(&uncategorized)
+
+		// Assert
+		assert.True(t, standard)
+		assert.False(t, books)
+	})
+
+	t.Run("MATCH_CATEGORIES_AND_TAGS", func(t *testing.T) {
+		// Act
+		category := MatchCategories("BOOKS").Matches(&p)
+		otherCategory := MatchCategories(CategoryFood).Matches(&p)
+		tag := MatchTags("bestseller").Matches(&p)
+		otherTag := MatchTags("clearance").Matches(&p)
+
+		// Assert
+		assert.True(t, category)
+		assert.False(t, otherCategory)
+		assert.True(t, tag)
+		assert.False(t, otherTag)
+	})
+
+	t.Run("MATCH_PARSE", func(t *testing.T) {
+		// Act
+		anyTerm, errAny := ParseMatcher("upc:654321; tag:classic")
+		none, errNone := ParseMatcher("range:200000-299999;category:food")
+		empty, errEmpty := ParseMatcher("")
+
+		// Assert
+		assert.NoError(t, errAny)
+		assert.NoError(t, errNone)
+		assert.NoError(t, errEmpty)
+		assert.True(t, anyTerm.Matches(&p))
+		assert.False(t, none.Matches(&p))
+		assert.Nil(t, empty)
+	})
+
+	t.Run("MATCH_PARSE_INVALID", func(t *testing.T) {
+		// Act
+		_, errKind := ParseMatcher("brand:acme")
+		_, errUPC := ParseMatcher("upc:12a456")
+		_, errRange := ParseMatcher("range:100000")
+		_, errEmpty := ParseMatcher("tag:")
+
+		// Assert
+		assert.ErrorIs(t, errKind, ErrInvalidMatcher)
+		assert.ErrorIs(t, errUPC, ErrInvalidMatcher)
+		assert.ErrorIs(t, errRange, ErrInvalidMatcher)
+		assert.ErrorIs(t, errEmpty, ErrInvalidMatcher)
+	})
+}
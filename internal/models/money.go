@@ -1,24 +1,161 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
 	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
 )
 
-// Money struct represents currency with amount and sign
+// Precision is the amount of decimal places all money amounts are stored and calculated with
+const Precision = 4
+
+// ErrCurrencyMismatch is returned when an operation is performed on money amounts in different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money struct represents currency with amount and sign.
+// Amount is stored as an integer in units of 10^-Precision, e.g. 20.25 is stored as 202500
 type Money struct {
 	Currency currency.CurrencyCode
-	Value    float64
+	Amount   int64
+}
+
+// NewMoney constructor function for Money types. Parses a decimal string and rounds it to the minor units of the currency,
+// invalid or negative values are set to 0. Refunds and credits are created with Neg or ParseMoney
+func NewMoney(currency currency.CurrencyCode, value string) Money {
+	m, err := ParseMoney(currency, value)
+	if err != nil || m.Amount < 0 {
+		m.Amount = 0
+	}
+
+	return m.RoundToMinorUnits(format.HalfUp)
 }
 
-// NewMoney constructor function for Money types
-func NewMoney(currency currency.CurrencyCode, value float64) Money {
-	if value < 0 {
-		value = 0
+// ParseMoney parses a decimal string into a Money type with the specified currency
+func ParseMoney(currency currency.CurrencyCode, value string) (Money, error) {
+	amount, err := format.ParseDecimal(value, Precision)
+	if err != nil {
+		return Money{Currency: currency}, err
 	}
 
 	return Money{
 		Currency: currency,
-		Value:    format.ToDecimal(value, 4),
+		Amount:   amount,
+	}, nil
+}
+
+// Add returns the sum of two money amounts, returns an error if the currencies differ
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{Currency: m.Currency}, fmt.Errorf("%w: can not add %v to %v", ErrCurrencyMismatch, other.Currency, m.Currency)
+	}
+
+	return Money{
+		Currency: m.Currency,
+		Amount:   m.Amount + other.Amount,
+	}, nil
+}
+
+// Sub returns the difference of two money amounts, returns an error if the currencies differ
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{Currency: m.Currency}, fmt.Errorf("%w: can not subtract %v from %v", ErrCurrencyMismatch, other.Currency, m.Currency)
 	}
+
+	return Money{
+		Currency: m.Currency,
+		Amount:   m.Amount - other.Amount,
+	}, nil
+}
+
+// MulRate multiplies the money amount by the ratio numerator/denominator, the result is rounded half-up to 4 decimal precision
+func (m Money) MulRate(numerator, denominator int64) Money {
+	return Money{
+		Currency: m.Currency,
+		Amount:   format.MulDivRound(m.Amount, numerator, denominator),
+	}
+}
+
+// Convert converts the money amount into another currency using an exchange rate with currency.RatePrecision decimals,
+// the result is rounded half-up to 4 decimal precision. Returns an error if the converted amount is too large to be stored
+func (m Money) Convert(to currency.CurrencyCode, rate int64) (Money, error) {
+	amount, err := format.MulDivRoundChecked(m.Amount, rate, format.Pow10(currency.RatePrecision))
+	if err != nil {
+		return Money{Currency: to}, fmt.Errorf("%w: can not convert %v to %v", err, m, to)
+	}
+
+	return Money{
+		Currency: to,
+		Amount:   amount,
+	}, nil
+}
+
+// Percent returns the specified percentage of the money amount with 4 decimal precision
+func (m Money) Percent(percentage utils.Percentage) Money {
+	return Money{
+		Currency: m.Currency,
+		Amount:   utils.AmountFromPercentage(percentage, m.Amount),
+	}
+}
+
+// Cmp compares two money amounts and returns -1 if m is less than other, 0 if they're equal and 1 if m is greater than other.
+// Returns an error if the currencies differ
+func (m Money) Cmp(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, fmt.Errorf("%w: can not compare %v to %v", ErrCurrencyMismatch, other.Currency, m.Currency)
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// IsZero checks if the money amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Neg returns the money amount with the opposite sign
+func (m Money) Neg() Money {
+	return Money{
+		Currency: m.Currency,
+		Amount:   -m.Amount,
+	}
+}
+
+// Abs returns the money amount without its sign
+func (m Money) Abs() Money {
+	if m.Amount < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Round returns the money amount rounded to the specified amount of decimal places using the specified rounding mode
+func (m Money) Round(places int, mode format.RoundingMode) Money {
+	return Money{
+		Currency: m.Currency,
+		Amount:   format.RoundUnitsMode(m.Amount, Precision, places, mode),
+	}
+}
+
+// RoundToMinorUnits returns the money amount rounded to the minor units of its currency, e.g. 2 decimals for USD, 0 for JPY
+func (m Money) RoundToMinorUnits(mode format.RoundingMode) Money {
+	return m.Round(m.Currency.MinorUnits(), mode)
+}
+
+// Format returns the money amount as a string with the specified amount of decimal places
+func (m Money) Format(places int) string {
+	return format.FormatUnits(m.Amount, Precision, places)
+}
+
+// String returns the money amount formatted with the minor units of its currency, followed by the currency code
+func (m Money) String() string {
+	return fmt.Sprintf("%v %v", m.Format(m.Currency.MinorUnits()), m.Currency)
 }
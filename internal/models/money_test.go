@@ -0,0 +1,303 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	t.Run("MONEY_ADD", func(t *testing.T) {
+		// Arrange
+		a := NewMoney(currency.USD, "20.25")
+		b := NewMoney(currency.USD, "4.05")
+
+		expectedResult := NewMoney(currency.USD, "24.30")
+
+		// Act
+		res, err := a.Add(b)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_SUB", func(t *testing.T) {
+		// Arrange
+		a := NewMoney(currency.USD, "20.25")
+		b := NewMoney(currency.USD, "1.42")
+
+		expectedResult := NewMoney(currency.USD, "18.83")
+
+		// Act
+		res, err := a.Sub(b)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_CURRENCY_MISMATCH", func(t *testing.T) {
+		// Arrange
+		a := NewMoney(currency.USD, "20.25")
+		b := NewMoney(currency.GBP, "2.20")
+
+		// Act
+		_, errAdd := a.Add(b)
+		_, errSub := a.Sub(b)
+		_, errCmp := a.Cmp(b)
+
+		// Assert
+		assert.ErrorIs(t, errAdd, ErrCurrencyMismatch)
+		assert.ErrorIs(t, errSub, ErrCurrencyMismatch)
+		assert.ErrorIs(t, errCmp, ErrCurrencyMismatch)
+	})
+
+	t.Run("MONEY_PERCENT", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.USD, "20.25")
+
+		expectedResult := Money{Currency: currency.USD, Amount: 42525}
+
+		// Act
+		res := m.Percent(utils.WholePercentage(21))
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_MUL_RATE", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.USD, "10")
+
+		expectedResult := Money{Currency: currency.USD, Amount: 33333}
+
+		// Act
+		res := m.MulRate(1, 3)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_CMP_NEG_IS_ZERO", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.USD, "4.05")
+
+		// Act
+		cmp, err := m.Cmp(m.Neg())
+		sum, _ := m.Add(m.Neg())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cmp)
+		assert.True(t, sum.IsZero())
+		assert.False(t, m.IsZero())
+	})
+
+	t.Run("MONEY_CONVERT", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.EUR, "20.25")
+		// 20.25 * 0.8535 = 17.283375
+		expectedResult := Money{Currency: currency.GBP, Amount: 172834}
+
+		// Act
+		res, err := m.Convert(currency.GBP, 85350000)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_CONVERT_OVERFLOW", func(t *testing.T) {
+		// Arrange
+		// the largest amount that can be stored, converted at 160 JPY per EUR
+		m := Money{Currency: currency.EUR, Amount: math.MaxInt64}
+
+		// Act
+		_, err := m.Convert(currency.JPY, 16000000000)
+
+		// Assert
+		assert.ErrorIs(t, err, format.ErrOverflow)
+	})
+}
+
+func TestMoneyAllocation(t *testing.T) {
+	t.Run("MONEY_SPLIT_REMAINDER", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.USD, "4.24")
+
+		expectedResult := []Money{
+			NewMoney(currency.USD, "1.42"),
+			NewMoney(currency.USD, "1.41"),
+			NewMoney(currency.USD, "1.41"),
+		}
+
+		// Act
+		res, err := m.Split(3)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_ALLOCATE_LARGEST_REMAINDER", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.USD, "0.05")
+
+		// 0.05 * 2/3 = 0.0333, 0.05 * 1/3 = 0.0166, the 0.01 remainder goes to the part that lost more
+		expectedResult := []Money{
+			NewMoney(currency.USD, "0.03"),
+			NewMoney(currency.USD, "0.02"),
+		}
+
+		// Act
+		res, err := m.Allocate(2, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_ALLOCATE_SUMS_TO_WHOLE", func(t *testing.T) {
+		// Arrange
+		m := Money{Currency: currency.USD, Amount: -42424}
+
+		// Act
+		res, err := m.Allocate(1, 1, 1, 2)
+		sum := Money{Currency: currency.USD}
+		for _, part := range res {
+			sum, _ = sum.Add(part)
+		}
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, m, sum)
+	})
+
+	t.Run("MONEY_ALLOCATE_NO_DECIMALS", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.JPY, "100")
+
+		expectedResult := []Money{
+			NewMoney(currency.JPY, "34"),
+			NewMoney(currency.JPY, "33"),
+			NewMoney(currency.JPY, "33"),
+		}
+
+		// Act
+		res, err := m.Split(3)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_ALLOCATE_LARGEST_AMOUNT", func(t *testing.T) {
+		// Arrange
+		// multiplying the amount with the ratios does not fit into an int64
+		m := Money{Currency: currency.USD, Amount: math.MaxInt64}
+
+		expectedResult := []Money{
+			{Currency: currency.USD, Amount: 3074457345618258602},
+			{Currency: currency.USD, Amount: 6148914691236517205},
+		}
+
+		// Act
+		res, err := m.Allocate(1, 2)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("MONEY_ALLOCATE_INVALID_RATIOS", func(t *testing.T) {
+		// Arrange
+		m := NewMoney(currency.USD, "10")
+
+		// Act
+		_, errEmpty := m.Allocate()
+		_, errZero := m.Allocate(0, 0)
+		_, errNegative := m.Allocate(1, -1)
+		_, errSplit := m.Split(0)
+
+		// Assert
+		assert.ErrorIs(t, errEmpty, ErrInvalidRatios)
+		assert.ErrorIs(t, errZero, ErrInvalidRatios)
+		assert.ErrorIs(t, errNegative, ErrInvalidRatios)
+		assert.ErrorIs(t, errSplit, ErrInvalidRatios)
+	})
+}
+
+func TestCashRounding(t *testing.T) {
+	t.Run("CASH_ROUNDING_CURRENCY_INCREMENT", func(t *testing.T) {
+		// Arrange
+		total := NewMoney(currency.CHF, "21.27")
+		expectedTotal := NewMoney(currency.CHF, "21.25")
+		expectedAdjustment := NewMoney(currency.CHF, "0.02").Neg()
+
+		// Act
+		res, adjustment := NewCurrencyCashRounding(format.HalfUp).Apply(total)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res)
+		assert.Equal(t, expectedAdjustment, adjustment)
+	})
+
+	t.Run("CASH_ROUNDING_STORE_INCREMENT", func(t *testing.T) {
+		// Arrange
+		total := NewMoney(currency.USD, "21.26")
+		expectedTotal := NewMoney(currency.USD, "21.30")
+		expectedAdjustment := NewMoney(currency.USD, "0.04")
+
+		// Act
+		res, adjustment := NewCashRounding("0.10", format.HalfUp).Apply(total)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res)
+		assert.Equal(t, expectedAdjustment, adjustment)
+	})
+
+	t.Run("CASH_ROUNDING_WHOLE_UNITS_DOWN", func(t *testing.T) {
+		// Arrange
+		total := NewMoney(currency.SEK, "149.90")
+		expectedTotal := NewMoney(currency.SEK, "149")
+		expectedAdjustment := NewMoney(currency.SEK, "0.90").Neg()
+
+		// Act
+		res, adjustment := NewCurrencyCashRounding(format.Down).Apply(total)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res)
+		assert.Equal(t, expectedAdjustment, adjustment)
+	})
+
+	t.Run("CASH_ROUNDING_CREDIT", func(t *testing.T) {
+		// Arrange
+		total := NewMoney(currency.CHF, "21.28").Neg()
+		expectedTotal := NewMoney(currency.CHF, "21.30").Neg()
+		expectedAdjustment := NewMoney(currency.CHF, "0.02").Neg()
+
+		// Act
+		res, adjustment := NewCurrencyCashRounding(format.HalfUp).Apply(total)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res)
+		assert.Equal(t, expectedAdjustment, adjustment)
+	})
+
+	t.Run("CASH_ROUNDING_NO_CASH_INCREMENT", func(t *testing.T) {
+		// Arrange
+		total := NewMoney(currency.USD, "21.27")
+
+		// Act
+		res, adjustment := NewCurrencyCashRounding(format.HalfUp).Apply(total)
+
+		// Assert
+		assert.Equal(t, total, res)
+		assert.True(t, adjustment.IsZero())
+	})
+}
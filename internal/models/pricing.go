@@ -0,0 +1,20 @@
+package models
+
+// Enum for the ways product prices are entered
+const (
+	PricingNet PricingMode = iota
+	PricingGross
+)
+
+// PricingMode defines if product prices are entered without tax (net) or with tax included (gross)
+type PricingMode uint16
+
+// NewPricingMode returns the pricing mode, invalid values use net pricing
+func NewPricingMode(mode uint16) PricingMode {
+	switch PricingMode(mode) {
+	case PricingGross:
+		return PricingGross
+	default:
+		return PricingNet
+	}
+}
@@ -9,16 +9,20 @@ import (
 
 // Product struct represents a product
 type Product struct {
-	name  string
-	upc   int
-	price Money
-	cost  Costs
+	name     string
+	upc      int
+	price    Money
+	cost     Costs
+	credit   bool
+	category TaxCategory
+	exempt   string
+	tags     []string
 }
 
 // NewProduct creates an instance of a new Product with the parameters set
 func NewProduct(name string, upc int, price Money, cost Costs) Product {
-	if price.Value < 0 {
-		price.Value = 0
+	if price.Amount < 0 {
+		price.Amount = 0
 	}
 
 	if name == "" {
@@ -38,6 +42,43 @@ func NewProduct(name string, upc int, price Money, cost Costs) Product {
 	}
 }
 
+// NewCreditProduct creates a product for refunds and credit notes. The price is stored as a negative amount
+// so that tax, discounts and expenses are all calculated as credits
+func NewCreditProduct(name string, upc int, price Money, cost Costs) Product {
+	price = price.Abs()
+
+	p := NewProduct(name, upc, price, cost)
+	p.price = price.Neg()
+	p.credit = true
+
+	return p
+}
+
+// WithTaxCategory returns a copy of the product with the tax category it is taxed by
+func (p Product) WithTaxCategory(category TaxCategory) Product {
+	p.category = category
+	return p
+}
+
+// WithTags returns a copy of the product with the tags that discounts can target, e.g. "bestseller" or "clearance"
+func (p Product) WithTags(tags ...string) Product {
+	p.tags = make([]string, len(tags))
+	for i, t := range tags {
+		p.tags[i] = normalizeTag(t)
+	}
+	return p
+}
+
+// WithTaxExemption returns a copy of the product that is never taxed, with the reason it is exempt from tax
+func (p Product) WithTaxExemption(reason string) Product {
+	if reason == "" {
+		reason = "Tax exempt product"
+	}
+
+	p.exempt = reason
+	return p
+}
+
 // generateUPC creates a new, randomized UPC that is 6 digits long
 func generateUPC() (int, error) {
 	maxLimit := int64(int(math.Pow10(6)) - 1)
@@ -80,3 +121,39 @@ func (p Product) Price() Money {
 func (p Product) Cost() Costs {
 	return p.cost
 }
+
+// IsCredit returns true if the product is priced as a refund or credit note
+func (p Product) IsCredit() bool {
+	return p.credit
+}
+
+// TaxCategory returns the tax category of the product, empty if the product uses the default category
+func (p Product) TaxCategory() TaxCategory {
+	return p.category
+}
+
+// IsTaxExempt returns true if the product is exempt from tax
+func (p Product) IsTaxExempt() bool {
+	return p.exempt != ""
+}
+
+// TaxExemptionReason returns the reason the product is exempt from tax, empty if it is taxed
+func (p Product) TaxExemptionReason() string {
+	return p.exempt
+}
+
+// Tags returns the tags of the product
+func (p Product) Tags() []string {
+	return p.tags
+}
+
+// HasTag checks if the product has the tag, tags are case insensitive
+func (p Product) HasTag(tag string) bool {
+	tag = normalizeTag(tag)
+	for _, t := range p.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// promotionDateLayouts are the layouts of the dates and times in discount files
+var promotionDateLayouts = []string{"2006-01-02 15:04", "2006-01-02"}
+
+// weekdays are the days of the week by their short names, as used in discount files
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// PromotionWindow is the time a discount is active in, e.g. a sales week or a happy hour on weekdays
+type PromotionWindow struct {
+	// ValidFrom is the first moment the discount is active, a zero time has no start
+	ValidFrom time.Time
+	// ValidTo is the moment the discount stops being active, a zero time has no end
+	ValidTo time.Time
+	// Days are the days of the week the discount is active on, empty for every day
+	Days []time.Weekday
+	// DailyFrom and DailyTo are the time of day the discount is active from and until, as the time since midnight.
+	// Equal times are active all day, and a window that ends before it starts runs past midnight
+	DailyFrom time.Duration
+	DailyTo   time.Duration
+	// Location is the time zone of the store the days and times of day are in, nil for UTC
+	Location *time.Location
+}
+
+// Active checks if the discount is active at the given time
+func (w PromotionWindow) Active(at time.Time) bool {
+	if !w.ValidFrom.IsZero() && at.Before(w.ValidFrom) {
+		return false
+	}
+	if !w.ValidTo.IsZero() && !at.Before(w.ValidTo) {
+		return false
+	}
+
+	location := w.Location
+	if location == nil {
+		location = time.UTC
+	}
+	// the time of day is read from the clock, so windows don't move on days the clocks change for daylight saving time
+	local := at.In(location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+
+	// the part of a window after midnight belongs to the day it started on
+	day := local.Weekday()
+	if w.DailyTo < w.DailyFrom && sinceMidnight < w.DailyTo {
+		day = (day + 6) % 7
+	}
+
+	if len(w.Days) > 0 && !containsWeekday(w.Days, day) {
+		return false
+	}
+
+	switch {
+	case w.DailyFrom == w.DailyTo:
+		return true
+	case w.DailyFrom < w.DailyTo:
+		return sinceMidnight >= w.DailyFrom && sinceMidnight < w.DailyTo
+	default:
+		return sinceMidnight >= w.DailyFrom || sinceMidnight < w.DailyTo
+	}
+}
+
+// ParsePromotionWindow parses a promotion window from its valid-from and valid-to dates (e.g. 2026-11-23 or 2026-11-23 09:00),
+// the days of the week separated by spaces (e.g. mon tue wed) and the time of day (e.g. 17:00-19:00) in the store's time zone.
+// Empty values have no limit
+func ParsePromotionWindow(validFrom, validTo, days, hours string, location *time.Location) (PromotionWindow, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	w := PromotionWindow{Location: location}
+
+	var err error
+	if w.ValidFrom, err = parsePromotionDate(validFrom, location); err != nil {
+		return w, err
+	}
+	if w.ValidTo, err = parsePromotionDate(validTo, location); err != nil {
+		return w, err
+	}
+
+	for _, d := range strings.Fields(days) {
+		day, ok := weekdays[strings.ToLower(d)]
+		if !ok {
+			return w, fmt.Errorf("%w: day %q", ErrInvalidDiscount, d)
+		}
+		w.Days = append(w.Days, day)
+	}
+
+	if strings.TrimSpace(hours) != "" {
+		from, to, found := strings.Cut(hours, "-")
+		if !found {
+			return w, fmt.Errorf("%w: hours %q", ErrInvalidDiscount, hours)
+		}
+		if w.DailyFrom, err = parseTimeOfDay(from); err != nil {
+			return w, err
+		}
+		if w.DailyTo, err = parseTimeOfDay(to); err != nil {
+			return w, err
+		}
+	}
+
+	return w, nil
+}
+
+// parsePromotionDate parses a date or a date and time in the store's time zone, an empty date is a zero time
+func parsePromotionDate(value string, location *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range promotionDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: date %q", ErrInvalidDiscount, value)
+}
+
+// parseTimeOfDay parses a time of day, e.g. 17:30, as the time since midnight. 24:00 is the end of the day
+func parseTimeOfDay(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: time of day %q", ErrInvalidDiscount, value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// containsWeekday checks if the day is in the list of days
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotionWindow(t *testing.T) {
+	// Friday, 27 November 2026
+	friday := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+
+	t.Run("PROMOTION_VALIDITY", func(t *testing.T) {
+		// Arrange
+		window := PromotionWindow{ValidFrom: friday, ValidTo: friday.AddDate(0, 0, 3)}
+
+		// Act
+		before := window.Active(friday.Add(-time.Second))
+		start := window.Active(friday)
+		end := window.Active(friday.AddDate(0, 0, 3))
+
+		// Assert
+		assert.False(t, before)
+		assert.True(t, start)
+		assert.False(t, end)
+	})
+
+	t.Run("PROMOTION_HAPPY_HOUR", func(t *testing.T) {
+		// Arrange
+		window := PromotionWindow{
+			Days:      []time.Weekday{time.Friday},
+			DailyFrom: 17 * time.Hour,
+			DailyTo:   19 * time.Hour,
+		}
+
+		// Act
+		during := window.Active(friday.Add(18 * time.Hour))
+		after := window.Active(friday.Add(19 * time.Hour))
+		otherDay := window.Active(friday.AddDate(0, 0, 1).Add(18 * time.Hour))
+
+		// Assert
+		assert.True(t, during)
+		assert.False(t, after)
+		assert.False(t, otherDay)
+	})
+
+	t.Run("PROMOTION_PAST_MIDNIGHT", func(t *testing.T) {
+		// Arrange
+		window := PromotionWindow{
+			Days:      []time.Weekday{time.Friday},
+			DailyFrom: 22 * time.Hour,
+			DailyTo:   2 * time.Hour,
+		}
+
+		// Act
+		fridayNight := window.Active(friday.Add(23 * time.Hour))
+		saturdayMorning := window.Active(friday.AddDate(0, 0, 1).Add(time.Hour))
+		fridayMorning := window.Active(friday.Add(time.Hour))
+
+		// Assert
+		assert.True(t, fridayNight)
+		assert.True(t, saturdayMorning)
+		assert.False(t, fridayMorning)
+	})
+
+	t.Run("PROMOTION_STORE_TIME_ZONE", func(t *testing.T) {
+		// Arrange
+		tokyo := time.FixedZone("JST", 9*60*60)
+		window, err := ParsePromotionWindow("", "", "fri", "17:00-19:00", tokyo)
+
+		// Act
+		tokyoHappyHour := window.Active(friday.Add(8 * time.Hour))
+		utcHappyHour := window.Active(friday.Add(18 * time.Hour))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, tokyoHappyHour)
+		assert.False(t, utcHappyHour)
+	})
+
+	t.Run("PROMOTION_DAYLIGHT_SAVING_SPRING_FORWARD", func(t *testing.T) {
+		// Arrange
+		london, _ := time.LoadLocation("Europe/London")
+		window, err := ParsePromotionWindow("", "", "", "17:00-19:00", london)
+
+		// Act
+		// the clocks go forward an hour on 29 March 2026
+		beforeLocal := window.Active(time.Date(2026, 3, 29, 16, 30, 0, 0, london))
+		duringLocal := window.Active(time.Date(2026, 3, 29, 17, 30, 0, 0, london))
+		afterLocal := window.Active(time.Date(2026, 3, 29, 19, 30, 0, 0, london))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, beforeLocal)
+		assert.True(t, duringLocal)
+		assert.False(t, afterLocal)
+	})
+
+	t.Run("PROMOTION_DAYLIGHT_SAVING_FALL_BACK", func(t *testing.T) {
+		// Arrange
+		london, _ := time.LoadLocation("Europe/London")
+		window, err := ParsePromotionWindow("", "", "", "17:00-19:00", london)
+
+		// Act
+		// the clocks go back an hour on 25 October 2026
+		beforeLocal := window.Active(time.Date(2026, 10, 25, 16, 30, 0, 0, london))
+		duringLocal := window.Active(time.Date(2026, 10, 25, 17, 30, 0, 0, london))
+		afterLocal := window.Active(time.Date(2026, 10, 25, 19, 0, 0, 0, london))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, beforeLocal)
+		assert.True(t, duringLocal)
+		assert.False(t, afterLocal)
+	})
+
+	t.Run("PROMOTION_PARSE_INVALID", func(t *testing.T) {
+		// Act
+		_, errDate := ParsePromotionWindow("27/11/2026", "", "", "", time.UTC)
+		_, errDay := ParsePromotionWindow("", "", "funday", "", time.UTC)
+		_, errHours := ParsePromotionWindow("", "", "", "17:00", time.UTC)
+
+		// Assert
+		assert.ErrorIs(t, errDate, ErrInvalidDiscount)
+		assert.ErrorIs(t, errDay, ErrInvalidDiscount)
+		assert.ErrorIs(t, errHours, ErrInvalidDiscount)
+	})
+}
@@ -0,0 +1,29 @@
+package models
+
+import "github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+
+// Rounding contains the rounding modes used when the final lines of a calculation are rounded to the minor units of a currency
+type Rounding struct {
+	Tax      format.RoundingMode
+	Discount format.RoundingMode
+	Expense  format.RoundingMode
+	Total    format.RoundingMode
+}
+
+// NewRounding constructor function for Rounding types. Invalid rounding modes are set to half-up rounding
+func NewRounding(tax, discount, expense, total format.RoundingMode) Rounding {
+	return Rounding{
+		Tax:      validRoundingMode(tax),
+		Discount: validRoundingMode(discount),
+		Expense:  validRoundingMode(expense),
+		Total:    validRoundingMode(total),
+	}
+}
+
+// validRoundingMode returns the rounding mode if it is one of the defined modes, otherwise returns half-up rounding
+func validRoundingMode(mode format.RoundingMode) format.RoundingMode {
+	if mode > format.Floor {
+		return format.HalfUp
+	}
+	return mode
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+)
+
+// scheduleDateLayout is the layout of the dates in tax schedule files
+const scheduleDateLayout = "2006-01-02"
+
+// ErrRateNotInForce is returned when no tax rate of a schedule is in force at the time of a transaction
+var ErrRateNotInForce = errors.New("no tax rate in force")
+
+// ScheduledRate is the tax rate of a tax category that is in force from its valid-from time until its valid-to time
+type ScheduledRate struct {
+	// Category is the tax category the rate applies to, empty for the default category
+	Category TaxCategory
+	Rate     utils.Percentage
+	// ValidFrom is the first moment the rate is in force, a zero time has no start
+	ValidFrom time.Time
+	// ValidTo is the moment the rate stops being in force, a zero time has no end
+	ValidTo time.Time
+}
+
+// TaxSchedule is a table of tax rates by tax category that change over time, e.g. VAT going from 20% to 21% on a given date.
+// Products without a category, or with a category that has no rate in force, are taxed with the rate of the default category
+type TaxSchedule struct {
+	rates           []ScheduledRate
+	defaultCategory TaxCategory
+}
+
+// NewTaxSchedule constructor function for tax schedules. Rates outside of 0 to 100 percent are limited to that range
+func NewTaxSchedule(defaultCategory TaxCategory, rates ...ScheduledRate) *TaxSchedule {
+	s := &TaxSchedule{
+		rates:           make([]ScheduledRate, len(rates)),
+		defaultCategory: normalizeCategory(defaultCategory),
+	}
+
+	for i, r := range rates {
+		r.Category = normalizeCategory(r.Category)
+		if r.Category == "" {
+			r.Category = s.defaultCategory
+		}
+		r.Rate = r.Rate.Clamp()
+		s.rates[i] = r
+	}
+
+	return s
+}
+
+// LoadTaxSchedule reads a tax schedule from a CSV file with the columns category,rate,valid_from,valid_to
+// (e.g. standard,21,2026-01-01,), where empty dates have no start or no end. Rates change at midnight in the time zone of the store.
+// A header row is skipped
+func LoadTaxSchedule(path string, defaultCategory TaxCategory, location *time.Location) (*TaxSchedule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadTaxSchedule(f, defaultCategory, location)
+}
+
+// ReadTaxSchedule reads a tax schedule in the CSV format described by LoadTaxSchedule
+func ReadTaxSchedule(r io.Reader, defaultCategory TaxCategory, location *time.Location) (*TaxSchedule, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []ScheduledRate
+	for i, record := range records {
+		// skip the header row
+		if i == 0 && strings.EqualFold(record[0], "category") {
+			continue
+		}
+
+		rate, err := utils.ParsePercentage(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		validFrom, err := parseScheduleDate(record[2], location)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		validTo, err := parseScheduleDate(record[3], location)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rates = append(rates, ScheduledRate{
+			Category:  TaxCategory(record[0]),
+			Rate:      rate,
+			ValidFrom: validFrom,
+			ValidTo:   validTo,
+		})
+	}
+
+	return NewTaxSchedule(defaultCategory, rates...), nil
+}
+
+// InForce checks if the rate is in force at the given time
+func (r ScheduledRate) InForce(at time.Time) bool {
+	if !r.ValidFrom.IsZero() && at.Before(r.ValidFrom) {
+		return false
+	}
+	return r.ValidTo.IsZero() || at.Before(r.ValidTo)
+}
+
+// RateAt returns the rate of the tax category in force at the given time, or the rate of the default category if the category has none.
+// If several rates are in force, the one that came into force last is used.
+// Returns an error if neither category has a rate in force
+func (s *TaxSchedule) RateAt(category TaxCategory, at time.Time) (utils.Percentage, error) {
+	if rate, ok := s.rateAt(normalizeCategory(category), at); ok {
+		return rate, nil
+	}
+	if rate, ok := s.rateAt(s.defaultCategory, at); ok {
+		return rate, nil
+	}
+	return 0, fmt.Errorf("%w: %q on %v", ErrRateNotInForce, category, at.Format(scheduleDateLayout))
+}
+
+// rateAt returns the rate of the category in force at the given time that came into force last
+func (s *TaxSchedule) rateAt(category TaxCategory, at time.Time) (utils.Percentage, bool) {
+	var found *ScheduledRate
+	for i, r := range s.rates {
+		if r.Category != category || !r.InForce(at) {
+			continue
+		}
+		if found == nil || r.ValidFrom.After(found.ValidFrom) {
+			found = &s.rates[i]
+		}
+	}
+
+	if found == nil {
+		return 0, false
+	}
+	return found.Rate, true
+}
+
+// DefaultCategory returns the category used for products without a category that has a rate in force
+func (s *TaxSchedule) DefaultCategory() TaxCategory {
+	return s.defaultCategory
+}
+
+// parseScheduleDate parses a date of a tax schedule file as midnight in the store's time zone, an empty date is a zero time
+func parseScheduleDate(date string, location *time.Location) (time.Time, error) {
+	if strings.TrimSpace(date) == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(scheduleDateLayout, strings.TrimSpace(date), location)
+}
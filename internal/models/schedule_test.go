@@ -0,0 +1,113 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxSchedule(t *testing.T) {
+	change := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	schedule := NewTaxSchedule(CategoryStandard,
+		ScheduledRate{Rate: utils.WholePercentage(20), ValidTo: change},
+		ScheduledRate{Rate: utils.WholePercentage(21), ValidFrom: change},
+		ScheduledRate{Category: "Books", Rate: utils.WholePercentage(5), ValidFrom: change},
+	)
+
+	t.Run("TAX_SCHEDULE_BEFORE_CHANGE", func(t *testing.T) {
+		// Arrange
+		expectedResult := utils.WholePercentage(20)
+
+		// Act
+		res, err := schedule.RateAt(CategoryStandard, change.Add(-time.Second))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TAX_SCHEDULE_ON_CHANGE", func(t *testing.T) {
+		// Arrange
+		expectedResult := utils.WholePercentage(21)
+
+		// Act
+		res, err := schedule.RateAt("", change)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TAX_SCHEDULE_CATEGORY_FALLBACK", func(t *testing.T) {
+		// Arrange
+		before := change.AddDate(0, -1, 0)
+
+		// Act
+		books, errBooks := schedule.RateAt(CategoryBooks, change)
+		fallback, errFallback := schedule.RateAt(CategoryBooks, before)
+
+		// Assert
+		assert.NoError(t, errBooks)
+		assert.NoError(t, errFallback)
+		assert.Equal(t, utils.WholePercentage(5), books)
+		assert.Equal(t, utils.WholePercentage(20), fallback)
+	})
+
+	t.Run("TAX_SCHEDULE_NOT_IN_FORCE", func(t *testing.T) {
+		// Arrange
+		s := NewTaxSchedule(CategoryStandard, ScheduledRate{Rate: utils.WholePercentage(21), ValidFrom: change})
+
+		// Act
+		_, err := s.RateAt(CategoryStandard, change.AddDate(0, 0, -1))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRateNotInForce)
+	})
+
+	t.Run("TAX_SCHEDULE_READ", func(t *testing.T) {
+		// Arrange
+		csv := "category,rate,valid_from,valid_to\nstandard,20,,2026-01-01\nstandard,21,2026-01-01,\n"
+
+		// Act
+		s, err := ReadTaxSchedule(strings.NewReader(csv), CategoryStandard, time.UTC)
+		res, _ := s.RateAt(CategoryDigital, change)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, utils.WholePercentage(21), res)
+	})
+
+	t.Run("TAX_SCHEDULE_READ_STORE_TIME_ZONE", func(t *testing.T) {
+		// Arrange
+		csv := "category,rate,valid_from,valid_to\nstandard,20,,2026-01-01\nstandard,21,2026-01-01,\n"
+		location, _ := time.LoadLocation("America/New_York")
+		// 23:30 on the day before the change in the store is already 04:30 on the day of the change in UTC
+		lateEvening := time.Date(2025, 12, 31, 23, 30, 0, 0, location)
+
+		// Act
+		s, err := ReadTaxSchedule(strings.NewReader(csv), CategoryStandard, location)
+		before, _ := s.RateAt(CategoryStandard, lateEvening)
+		after, _ := s.RateAt(CategoryStandard, lateEvening.Add(time.Hour))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, utils.WholePercentage(20), before)
+		assert.Equal(t, utils.WholePercentage(21), after)
+	})
+
+	t.Run("TAX_SCHEDULE_READ_INVALID", func(t *testing.T) {
+		// Arrange
+		csv := "standard,21,01/01/2026,\n"
+
+		// Act
+		_, err := ReadTaxSchedule(strings.NewReader(csv), CategoryStandard, time.UTC)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -1,23 +1,60 @@
 package models
 
+import "github.com/radoslavboychev/price-calculator-kata/internal/utils"
+
+// defaultTaxName is the name taxes are reported with when they've not been named
+const defaultTaxName = "Tax"
+
 // Tax contains a tax rate and a tax amount
 type Tax struct {
-	rate   uint16
-	Amount Money
+	name     string
+	rate     utils.Percentage
+	compound bool
+	Amount   Money
 }
 
-// NewTax constructor function for Tax types
-func NewTax(rate uint16) *Tax {
-	if rate > 100 {
-		rate = 100
-	}
+// TaxLine is a single tax amount as it is reported
+type TaxLine struct {
+	Description string
+	Amount      Money
+}
 
+// NewTax constructor function for Tax types. Rates outside of 0 to 100 percent are limited to that range
+func NewTax(rate utils.Percentage) *Tax {
 	return &Tax{
-		rate: rate,
+		rate: rate.Clamp(),
 	}
 }
 
+// NewSimpleTax constructor function for named taxes that are calculated on the taxable price only
+func NewSimpleTax(name string, rate utils.Percentage) *Tax {
+	t := NewTax(rate)
+	t.name = name
+	return t
+}
+
+// NewCompoundTax constructor function for named taxes that are calculated on the taxable price
+// plus all of the taxes that come before them, e.g. Quebec QST on top of GST
+func NewCompoundTax(name string, rate utils.Percentage) *Tax {
+	t := NewSimpleTax(name, rate)
+	t.compound = true
+	return t
+}
+
 // Rate returns the tax rate
-func (t *Tax) Rate() uint16 {
+func (t *Tax) Rate() utils.Percentage {
 	return t.rate
 }
+
+// Name returns the name the tax is reported with
+func (t *Tax) Name() string {
+	if t.name == "" {
+		return defaultTaxName
+	}
+	return t.name
+}
+
+// IsCompound returns true if the tax is calculated on top of the taxes that come before it
+func (t *Tax) IsCompound() bool {
+	return t.compound
+}
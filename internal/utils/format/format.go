@@ -1,43 +1,285 @@
 package format
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
-// ToDecimal rounds a float to a specified precision
-func ToDecimal(input float64, places int) (rounded float64) {
-	// If the float is not a number
-	if math.IsNaN(input) {
-		return math.NaN()
+// ErrInvalidDecimal is returned when a string can not be parsed as a decimal number
+var ErrInvalidDecimal = errors.New("invalid decimal number")
+
+// ErrOverflow is returned when the result of a calculation does not fit into an int64
+var ErrOverflow = errors.New("integer overflow")
+
+// Enum for the rounding modes that can be used when reducing precision
+const (
+	// HalfUp rounds to the nearest neighbour, ties are rounded away from zero
+	HalfUp RoundingMode = iota
+	// HalfEven rounds to the nearest neighbour, ties are rounded to the even neighbour (banker's rounding)
+	HalfEven
+	// HalfDown rounds to the nearest neighbour, ties are rounded towards zero
+	HalfDown
+	// Up always rounds away from zero
+	Up
+	// Down always rounds towards zero (truncation)
+	Down
+	// Ceiling always rounds towards positive infinity
+	Ceiling
+	// Floor always rounds towards negative infinity
+	Floor
+)
+
+// RoundingMode defines an enum for the different rounding modes
+type RoundingMode uint16
+
+// String represents a rounding mode as string for printing purposes
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfUp:
+		return "HALF_UP"
+	case HalfEven:
+		return "HALF_EVEN"
+	case HalfDown:
+		return "HALF_DOWN"
+	case Up:
+		return "UP"
+	case Down:
+		return "DOWN"
+	case Ceiling:
+		return "CEILING"
+	case Floor:
+		return "FLOOR"
+	}
+	return "UNKNOWN ROUNDING MODE"
+}
+
+// roundsAway decides if a number with a discarded fraction gets rounded away from zero.
+// half is the comparison of the discarded fraction to one half (-1 when below, 0 on a tie, 1 when above)
+func roundsAway(hasFraction bool, half int, even, negative bool, mode RoundingMode) bool {
+	if !hasFraction {
+		return false
+	}
+
+	switch mode {
+	case Up:
+		return true
+	case Down:
+		return false
+	case Ceiling:
+		return !negative
+	case Floor:
+		return negative
+	case HalfEven:
+		if half == 0 {
+			return !even
+		}
+	case HalfDown:
+		if half == 0 {
+			return false
+		}
+	}
+
+	return half >= 0
+}
+
+// Pow10 returns 10 to the power of n as an integer. Negative values of n return 1
+func Pow10(n int) int64 {
+	var result int64 = 1
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
+
+// DivRound divides two integers and rounds the result half away from zero
+func DivRound(numerator, denominator int64) int64 {
+	return DivRoundMode(numerator, denominator, HalfUp)
+}
+
+// DivRoundMode divides two integers and rounds the result using the specified rounding mode
+func DivRoundMode(numerator, denominator int64, mode RoundingMode) int64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	if denominator < 0 {
+		numerator, denominator = -numerator, -denominator
+	}
+
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	if remainder < 0 {
+		remainder = -remainder
+	}
+
+	// the remainder is compared to the rest of the denominator, so it is compared to one half without overflowing
+	half := 0
+	switch {
+	case remainder < denominator-remainder:
+		half = -1
+	case remainder > denominator-remainder:
+		half = 1
+	}
+
+	// Decide if the quotient is rounded away from zero depending on the mode
+	if roundsAway(remainder != 0, half, quotient%2 == 0, numerator < 0, mode) {
+		if numerator < 0 {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+
+	return quotient
+}
+
+// MulDivRound multiplies two integers and divides the product by the denominator, rounding half away from zero.
+// The product is calculated without overflowing. Results that do not fit into an int64 are saturated to the largest
+// or smallest int64, use MulDivRoundChecked where that can happen
+func MulDivRound(a, b, denominator int64) int64 {
+	result, _ := MulDivRoundChecked(a, b, denominator)
+	return result
+}
+
+// MulDivRoundChecked multiplies two integers and divides the product by the denominator, rounding half away from zero.
+// Returns ErrOverflow together with the saturated result if the result does not fit into an int64
+func MulDivRoundChecked(a, b, denominator int64) (int64, error) {
+	if denominator == 0 {
+		return 0, nil
+	}
+
+	product := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	d := big.NewInt(denominator)
+	if denominator < 0 {
+		product.Neg(product)
+		d.Neg(d)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(product, d, new(big.Int))
+
+	// If the remainder is at least half of the denominator we round away from zero
+	remainder.Abs(remainder).Mul(remainder, big.NewInt(2))
+	if remainder.Cmp(d) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		if quotient.Sign() < 0 {
+			return math.MinInt64, ErrOverflow
+		}
+		return math.MaxInt64, ErrOverflow
 	}
 
+	return quotient.Int64(), nil
+}
+
+// RoundUnits rounds an integer holding a number with `from` decimal places to `places` decimal places, half away from zero.
+// The result is still expressed with `from` decimal places, e.g. RoundUnits(42525, 4, 2) = 42500
+func RoundUnits(units int64, from, places int) int64 {
+	return RoundUnitsMode(units, from, places, HalfUp)
+}
+
+// RoundUnitsMode rounds an integer holding a number with `from` decimal places to `places` decimal places
+// using the specified rounding mode
+func RoundUnitsMode(units int64, from, places int, mode RoundingMode) int64 {
 	if places < 0 {
 		places = 0
 	}
 
-	// Find out the actual sign and correct the input for later
-	sign := 1.0
-	if input < 0 {
-		sign = -1
-		input *= -1
+	if places >= from {
+		return units
 	}
 
-	// Use the places arg to get the amount of precision wanted
-	precision := math.Pow(10, float64(places))
+	step := Pow10(from - places)
+	return DivRoundMode(units, step, mode) * step
+}
 
-	// Find the decimal place we are looking to round
-	digit := input * precision
+// FormatUnits formats an integer holding a number with `from` decimal places as a string with `places` decimal places
+func FormatUnits(units int64, from, places int) string {
+	if places < 0 {
+		places = 0
+	}
 
-	// Get the actual decimal number as a fraction to be compared
-	_, decimal := math.Modf(digit)
+	rounded := RoundUnits(units, from, places)
 
-	// If the decimal is less than .5 we round down otherwise up
-	if decimal >= 0.5 {
-		rounded = math.Ceil(digit)
+	sign := ""
+	if rounded < 0 {
+		sign = "-"
+		rounded = -rounded
+	}
+
+	// scale the number to the amount of places that will be printed
+	if places <= from {
+		rounded /= Pow10(from - places)
 	} else {
-		rounded = math.Floor(digit)
+		rounded *= Pow10(places - from)
+	}
+
+	if places == 0 {
+		return sign + strconv.FormatInt(rounded, 10)
+	}
+
+	scale := Pow10(places)
+	return fmt.Sprintf("%v%d.%0*d", sign, rounded/scale, places, rounded%scale)
+}
+
+// ParseDecimal parses a decimal string such as "20.25" into an integer with the specified amount of decimal places.
+// Digits beyond the requested precision are rounded half away from zero
+func ParseDecimal(input string, places int) (int64, error) {
+	if places < 0 {
+		places = 0
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, ErrInvalidDecimal
+	}
+
+	// Find out the actual sign and correct the input for later
+	negative := false
+	switch input[0] {
+	case '-':
+		negative = true
+		input = input[1:]
+	case '+':
+		input = input[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(input, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalidDecimal
+	}
+
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return 0, ErrInvalidDecimal
+		}
+	}
+
+	// pad the fraction with zeroes and keep one extra digit for rounding
+	fraction += strings.Repeat("0", places+1)
+	digits := strings.TrimLeft(whole+fraction[:places+1], "0")
+	if digits == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidDecimal
+	}
+
+	result := DivRound(value, 10)
+	if negative {
+		result = -result
 	}
 
-	// Finally we do the math to actually create a rounded number
-	return rounded / precision * sign
+	return result, nil
 }
@@ -1,81 +1,220 @@
 package format
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func TestToDecimal(t *testing.T) {
-	t.Run("TEST_TODECIMAL_CONVERSION", func(t *testing.T) {
+func TestParseDecimal(t *testing.T) {
+	t.Run("TEST_PARSEDECIMAL_DEFAULT", func(t *testing.T) {
 		// Arrange
-		var input float64 = 20.2564353
+		var input string = "20.25"
 		var places int = 4
 
-		expectedResult := 20.2564
+		var expectedResult int64 = 202500
 
 		// Act
-		res := ToDecimal(input, places)
+		res, err := ParseDecimal(input, places)
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 	})
 
-	t.Run("TEST_TODECIMAL_NIL_INPUT", func(t *testing.T) {
+	t.Run("TEST_PARSEDECIMAL_ROUNDING", func(t *testing.T) {
 		// Arrange
-		var input float64 = 0
+		var input string = "-1.204875"
 		var places int = 4
 
-		var expectedResult float64 = 0
+		var expectedResult int64 = -12049
 
 		// Act
-		res := ToDecimal(input, places)
+		res, err := ParseDecimal(input, places)
 
 		// Assert
+		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, res)
 	})
 
-	t.Run("TEST_TODECIMAL_NEGATIVE_INPUT", func(t *testing.T) {
+	t.Run("TEST_PARSEDECIMAL_INVALID_INPUT", func(t *testing.T) {
 		// Arrange
-		var input float64 = -5.048372
+		var input string = "20.2a"
 		var places int = 4
 
-		var expectedResult float64 = -5.0484
+		// Act
+		_, err := ParseDecimal(input, places)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidDecimal)
+	})
+}
+
+func TestRoundUnits(t *testing.T) {
+	t.Run("TEST_ROUNDUNITS_HALF_AWAY_FROM_ZERO", func(t *testing.T) {
+		// Arrange
+		var input int64 = 42525
+
+		var expectedResult int64 = 42500
 
 		// Act
-		res := ToDecimal(input, places)
+		res := RoundUnits(input, 4, 2)
 
 		// Assert
 		assert.Equal(t, expectedResult, res)
 	})
 
-	t.Run("TEST_TODECIMAL_NEGATIVE_PLACES", func(t *testing.T) {
+	t.Run("TEST_ROUNDUNITS_NEGATIVE_INPUT", func(t *testing.T) {
 		// Arrange
-		var input float64 = 5.048372
+		var input int64 = -44550
 
-		// if places < 0, they're set to 0, removing the decimal spaces
-		var places int = -5
+		var expectedResult int64 = -44600
 
-		var expectedResult float64 = 5
+		// Act
+		res := RoundUnits(input, 4, 2)
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+}
+
+func TestFormatUnits(t *testing.T) {
+	t.Run("TEST_FORMATUNITS_DEFAULT", func(t *testing.T) {
+		// Arrange
+		var input int64 = 208676
+
+		expectedResult := "20.87"
 
 		// Act
-		res := ToDecimal(input, places)
+		res := FormatUnits(input, 4, 2)
 
 		// Assert
 		assert.Equal(t, expectedResult, res)
 	})
 
-	t.Run("TEST_TODECIMAL_ZERO_PLACES", func(t *testing.T) {
+	t.Run("TEST_FORMATUNITS_NEGATIVE_INPUT", func(t *testing.T) {
 		// Arrange
-		var input float64 = 5.048372
-		var places int = 0
+		var input int64 = -500
 
-		var expectedResult float64 = 5
+		expectedResult := "-0.05"
 
 		// Act
-		res := ToDecimal(input, places)
+		res := FormatUnits(input, 4, 2)
 
 		// Assert
 		assert.Equal(t, expectedResult, res)
 	})
 }
+
+func TestRoundUnitsMode(t *testing.T) {
+	// each case rounds a value with 4 decimal places to 2 decimal places
+	cases := []struct {
+		name           string
+		input          int64
+		mode           RoundingMode
+		expectedResult int64
+	}{
+		{"TEST_ROUNDUNITSMODE_HALF_UP_TIE", 20250, HalfUp, 20300},
+		{"TEST_ROUNDUNITSMODE_HALF_EVEN_TIE_DOWN", 20250, HalfEven, 20200},
+		{"TEST_ROUNDUNITSMODE_HALF_EVEN_TIE_UP", 20350, HalfEven, 20400},
+		{"TEST_ROUNDUNITSMODE_HALF_EVEN_NO_TIE", 20251, HalfEven, 20300},
+		{"TEST_ROUNDUNITSMODE_HALF_DOWN_TIE", 20250, HalfDown, 20200},
+		{"TEST_ROUNDUNITSMODE_UP", 20201, Up, 20300},
+		{"TEST_ROUNDUNITSMODE_DOWN", 20299, Down, 20200},
+		{"TEST_ROUNDUNITSMODE_CEILING_NEGATIVE", -20299, Ceiling, -20200},
+		{"TEST_ROUNDUNITSMODE_FLOOR_NEGATIVE", -20201, Floor, -20300},
+		{"TEST_ROUNDUNITSMODE_NEGATIVE_HALF_UP_TIE", -20250, HalfUp, -20300},
+		{"TEST_ROUNDUNITSMODE_EXACT", 20200, Up, 20200},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Act
+			res := RoundUnitsMode(c.input, 4, 2, c.mode)
+
+			// Assert
+			assert.Equal(t, c.expectedResult, res)
+		})
+	}
+}
+
+func TestMulDivRound(t *testing.T) {
+	t.Run("TEST_MULDIVROUND_DEFAULT", func(t *testing.T) {
+		// Arrange
+		// 20.25 * 0.8535 = 17.283375
+		var amount int64 = 202500
+		var rate int64 = 85350000
+
+		var expectedResult int64 = 172834
+
+		// Act
+		res := MulDivRound(amount, rate, Pow10(8))
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TEST_MULDIVROUND_NEGATIVE", func(t *testing.T) {
+		// Arrange
+		var amount int64 = -202500
+		var rate int64 = 85350000
+
+		var expectedResult int64 = -172834
+
+		// Act
+		res := MulDivRound(amount, rate, Pow10(8))
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TEST_MULDIVROUND_NO_OVERFLOW", func(t *testing.T) {
+		// Arrange
+		var amount int64 = 9_000_000_000_000
+		var rate int64 = 200000000
+
+		var expectedResult int64 = 18_000_000_000_000
+
+		// Act
+		res := MulDivRound(amount, rate, Pow10(8))
+
+		// Assert
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("TEST_MULDIVROUND_BOUNDARY", func(t *testing.T) {
+		// Arrange
+		var amount int64 = math.MaxInt64
+		var rate int64 = 100000000
+
+		// Act
+		res, err := MulDivRoundChecked(amount, rate, Pow10(8))
+		negative, errNegative := MulDivRoundChecked(math.MinInt64, rate, Pow10(8))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, errNegative)
+		assert.Equal(t, amount, res)
+		assert.Equal(t, int64(math.MinInt64), negative)
+	})
+
+	t.Run("TEST_MULDIVROUND_OVERFLOW", func(t *testing.T) {
+		// Arrange
+		// 922,337,203,685,477.5807 at 4 decimals converted at 160 overflows
+		var amount int64 = math.MaxInt64
+		var rate int64 = 16000000000
+
+		// Act
+		res, err := MulDivRoundChecked(amount, rate, Pow10(8))
+		negative, errNegative := MulDivRoundChecked(-amount, rate, Pow10(8))
+		saturated := MulDivRound(amount, rate, Pow10(8))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrOverflow)
+		assert.ErrorIs(t, errNegative, ErrOverflow)
+		assert.Equal(t, int64(math.MaxInt64), res)
+		assert.Equal(t, int64(math.MinInt64), negative)
+		assert.Equal(t, int64(math.MaxInt64), saturated)
+	})
+}
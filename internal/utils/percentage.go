@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+)
+
+// PercentagePrecision is the amount of decimal places percentages are stored with
+const PercentagePrecision = 4
+
+// HundredPercent is the largest valid percentage
+const HundredPercent Percentage = 1000000
+
+// ErrInvalidPercentage is returned when a percentage can not be parsed or is not between 0 and 100
+var ErrInvalidPercentage = errors.New("invalid percentage")
+
+// Percentage is a percentage stored as an integer with PercentagePrecision decimals, e.g. 8.875% is stored as 88750
+type Percentage int64
+
+// WholePercentage returns the percentage for a whole number of percents, e.g. 21 for 21%
+func WholePercentage(percentage uint16) Percentage {
+	return Percentage(int64(percentage) * format.Pow10(PercentagePrecision))
+}
+
+// ParsePercentage parses a percentage from its decimal string representation, e.g. "8.875" for 8.875%.
+// Returns an error if the value can not be parsed or is not between 0 and 100
+func ParsePercentage(value string) (Percentage, error) {
+	units, err := format.ParseDecimal(value, PercentagePrecision)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPercentage, value)
+	}
+
+	p := Percentage(units)
+	if !p.Valid() {
+		return 0, fmt.Errorf("%w: %q is not between 0 and 100", ErrInvalidPercentage, value)
+	}
+
+	return p, nil
+}
+
+// Valid checks if the percentage is between 0 and 100
+func (p Percentage) Valid() bool {
+	return p >= 0 && p <= HundredPercent
+}
+
+// Clamp returns the percentage limited to values between 0 and 100
+func (p Percentage) Clamp() Percentage {
+	if p < 0 {
+		return 0
+	}
+	if p > HundredPercent {
+		return HundredPercent
+	}
+	return p
+}
+
+// String formats the percentage without its trailing zeros, e.g. "8.875"
+func (p Percentage) String() string {
+	str := format.FormatUnits(int64(p), PercentagePrecision, PercentagePrecision)
+	str = strings.TrimRight(str, "0")
+	return strings.TrimSuffix(str, ".")
+}
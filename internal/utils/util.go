@@ -2,7 +2,8 @@ package utils
 
 import "github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
 
-// AmountFromPercentage calculates the absolute amount from percentage and returns the result as a float with 4 decimal precision
-func AmountFromPercentage(percentage uint16, price float64) (result float64) {
-	return format.ToDecimal(((float64(percentage) / 100) * price), 4)
+// AmountFromPercentage calculates the absolute amount from percentage of an amount stored with 4 decimal precision,
+// the result is rounded and stored with the same precision
+func AmountFromPercentage(percentage Percentage, amount int64) (result int64) {
+	return format.MulDivRound(amount, int64(percentage), int64(HundredPercent))
 }
@@ -9,10 +9,10 @@ import (
 func TestAmountFromPercentage(t *testing.T) {
 	// Test case when percentage is zero, should return 0
 	t.Run("AMOUNT_FROM_PERCENTAGE_ZERO_PERCENT", func(t *testing.T) {
-		var percentage uint16 = 0
-		var price float64 = 20
+		percentage := WholePercentage(0)
+		var price int64 = 200000
 
-		var expectedResult float64 = 0.00
+		var expectedResult int64 = 0
 
 		res := AmountFromPercentage(percentage, price)
 
@@ -21,13 +21,84 @@ func TestAmountFromPercentage(t *testing.T) {
 
 	// Test case when percentage is properly set
 	t.Run("AMOUNT_FROM_PERCENTAGE_DEFAULT", func(t *testing.T) {
-		var percentage uint16 = 10
-		var price float64 = 20
+		percentage := WholePercentage(10)
+		var price int64 = 200000
 
-		var expectedResult float64 = 2
+		var expectedResult int64 = 20000
 
 		res := AmountFromPercentage(percentage, price)
 
 		assert.Equal(t, expectedResult, res)
 	})
+
+	// Test case when the result has to be rounded to 4 decimal precision
+	t.Run("AMOUNT_FROM_PERCENTAGE_ROUNDING", func(t *testing.T) {
+		percentage := WholePercentage(7)
+		var price int64 = 172125
+
+		var expectedResult int64 = 12049
+
+		res := AmountFromPercentage(percentage, price)
+
+		assert.Equal(t, expectedResult, res)
+	})
+
+	// Test case when the percentage has decimals
+	t.Run("AMOUNT_FROM_PERCENTAGE_FRACTIONAL", func(t *testing.T) {
+		percentage, _ := ParsePercentage("8.875")
+		var price int64 = 202500
+
+		// 20.25 * 8.875% = 1.7971875
+		var expectedResult int64 = 17972
+
+		res := AmountFromPercentage(percentage, price)
+
+		assert.Equal(t, expectedResult, res)
+	})
+}
+
+func TestParsePercentage(t *testing.T) {
+	t.Run("PARSE_PERCENTAGE_DEFAULT", func(t *testing.T) {
+		var expectedResult Percentage = 88750
+
+		res, err := ParsePercentage("8.875")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+		assert.Equal(t, "8.875", res.String())
+	})
+
+	t.Run("PARSE_PERCENTAGE_FOUR_DECIMALS", func(t *testing.T) {
+		var expectedResult Percentage = 99750
+
+		res, err := ParsePercentage("9.9750")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("PARSE_PERCENTAGE_LIMITS", func(t *testing.T) {
+		resZero, errZero := ParsePercentage("0")
+		resHundred, errHundred := ParsePercentage("100")
+
+		assert.NoError(t, errZero)
+		assert.NoError(t, errHundred)
+		assert.Equal(t, Percentage(0), resZero)
+		assert.Equal(t, HundredPercent, resHundred)
+	})
+
+	t.Run("PARSE_PERCENTAGE_OUT_OF_RANGE", func(t *testing.T) {
+		_, errHigh := ParsePercentage("100.0001")
+		_, errNegative := ParsePercentage("-0.5")
+		_, errInvalid := ParsePercentage("12,5")
+
+		assert.ErrorIs(t, errHigh, ErrInvalidPercentage)
+		assert.ErrorIs(t, errNegative, ErrInvalidPercentage)
+		assert.ErrorIs(t, errInvalid, ErrInvalidPercentage)
+	})
+
+	t.Run("PERCENTAGE_CLAMP", func(t *testing.T) {
+		assert.Equal(t, HundredPercent, WholePercentage(500).Clamp())
+		assert.Equal(t, Percentage(0), Percentage(-10).Clamp())
+	})
 }
@@ -0,0 +1,44 @@
+package vat
+
+import "github.com/radoslavboychev/price-calculator-kata/internal/utils"
+
+// standardRates stores the standard VAT rates of the EU member states by their VAT country code.
+// Greece uses EL as its VAT country code. The rates have to be kept up to date when member states change them
+var standardRates = map[string]string{
+	"AT": "20",
+	"BE": "21",
+	"BG": "20",
+	"CY": "19",
+	"CZ": "21",
+	"DE": "19",
+	"DK": "25",
+	"EE": "24",
+	"EL": "24",
+	"ES": "21",
+	"FI": "25.5",
+	"FR": "20",
+	"HR": "25",
+	"HU": "27",
+	"IE": "23",
+	"IT": "22",
+	"LT": "21",
+	"LU": "17",
+	"LV": "21",
+	"MT": "18",
+	"NL": "21",
+	"PL": "23",
+	"PT": "23",
+	"RO": "21",
+	"SE": "25",
+	"SI": "22",
+	"SK": "23",
+}
+
+// StandardRates returns the standard VAT rates of the EU member states by their VAT country code
+func StandardRates() map[string]utils.Percentage {
+	rates := make(map[string]utils.Percentage, len(standardRates))
+	for country, rate := range standardRates {
+		rates[country], _ = utils.ParsePercentage(rate)
+	}
+	return rates
+}
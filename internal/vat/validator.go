@@ -0,0 +1,454 @@
+package vat
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVATID is returned when a VAT ID does not have the format or the check digits of its country
+var ErrInvalidVATID = errors.New("invalid VAT ID")
+
+// vatIDFormats stores the format of the VAT IDs of every EU member state, without the country code
+var vatIDFormats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+}
+
+// vatIDChecksums stores the check digit algorithms of every EU member state
+var vatIDChecksums = map[string]func(number string) bool{
+	"AT": checkAT,
+	"BE": checkBE,
+	"BG": checkBG,
+	"CY": checkCY,
+	"CZ": checkCZ,
+	"DE": checkMod1110,
+	"DK": checkDK,
+	"EE": checkEE,
+	"EL": checkEL,
+	"ES": checkES,
+	"FI": checkFI,
+	"FR": checkFR,
+	"HR": checkMod1110,
+	"HU": checkHU,
+	"IE": checkIE,
+	"IT": checkLuhn,
+	"LT": checkLT,
+	"LU": checkLU,
+	"LV": checkLV,
+	"MT": checkMT,
+	"NL": checkNL,
+	"PL": checkPL,
+	"PT": checkPT,
+	"RO": checkRO,
+	"SE": checkSE,
+	"SI": checkSI,
+	"SK": checkSK,
+}
+
+// ValidateVATID validates the format and the check digits of a VAT ID, e.g. "DE136695976".
+// Spaces, dots and dashes are ignored. Validation is offline, it does not check if the VAT ID is registered.
+// Returns the VAT country code of the VAT ID
+func ValidateVATID(id string) (string, error) {
+	normalized := strings.ToUpper(id)
+	normalized = strings.NewReplacer(" ", "", ".", "", "-", "").Replace(normalized)
+
+	if len(normalized) < 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidVATID, id)
+	}
+
+	country, number := normalized[:2], normalized[2:]
+
+	format, ok := vatIDFormats[country]
+	if !ok {
+		return "", fmt.Errorf("%w: %q: %v", ErrInvalidVATID, id, ErrUnknownCountry)
+	}
+
+	if !format.MatchString(number) {
+		return "", fmt.Errorf("%w: %q does not match the format of %v", ErrInvalidVATID, id, country)
+	}
+
+	if check, ok := vatIDChecksums[country]; ok && !check(number) {
+		return "", fmt.Errorf("%w: %q has invalid check digits", ErrInvalidVATID, id)
+	}
+
+	return country, nil
+}
+
+// digits converts a string of digits to their values
+func digits(number string) []int {
+	d := make([]int, len(number))
+	for i, r := range number {
+		d[i] = int(r - '0')
+	}
+	return d
+}
+
+// weightedSum returns the sum of the digits multiplied by their weights
+func weightedSum(number string, weights ...int) int {
+	sum := 0
+	for i, d := range digits(number[:len(weights)]) {
+		sum += d * weights[i]
+	}
+	return sum
+}
+
+// checkAT validates Austrian VAT IDs, U followed by 7 digits and a check digit
+func checkAT(number string) bool {
+	d := digits(number[1:])
+	sum := 0
+	for i := 0; i < 7; i++ {
+		if i%2 == 1 {
+			doubled := d[i] * 2
+			sum += doubled/10 + doubled%10
+		} else {
+			sum += d[i]
+		}
+	}
+	return (10-(sum+4)%10)%10 == d[7]
+}
+
+// checkBE validates Belgian VAT IDs, the last 2 digits are 97 minus the first 8 digits modulo 97
+func checkBE(number string) bool {
+	base, _ := strconv.Atoi(number[:8])
+	check, _ := strconv.Atoi(number[8:])
+	return 97-base%97 == check
+}
+
+// checkMod1110 validates VAT IDs with an ISO 7064 MOD 11,10 check digit, used by Germany and Croatia
+func checkMod1110(number string) bool {
+	d := digits(number)
+	product := 10
+	for _, digit := range d[:len(d)-1] {
+		sum := (digit + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == d[len(d)-1]
+}
+
+// checkBG validates Bulgarian VAT IDs, the 9 digit number of a legal entity or the 10 digit personal number of a citizen,
+// a foreigner or another taxpayer
+func checkBG(number string) bool {
+	d := digits(number)
+	if len(d) == 9 {
+		check := weightedSum(number, 1, 2, 3, 4, 5, 6, 7, 8) % 11
+		if check == 10 {
+			check = weightedSum(number, 3, 4, 5, 6, 7, 8, 9, 10) % 11
+		}
+		return check%10 == d[8]
+	}
+
+	citizen := weightedSum(number, 2, 4, 8, 5, 10, 9, 7, 3, 6) % 11 % 10
+	foreigner := weightedSum(number, 21, 19, 17, 13, 11, 9, 7, 3, 1) % 10
+	other := (11 - weightedSum(number, 4, 3, 2, 7, 6, 5, 4, 3, 2)%11) % 11
+	return citizen == d[9] || foreigner == d[9] || other == d[9]
+}
+
+// checkCY validates Cypriot VAT IDs, the check letter is calculated from the 8 digits. Numbers never start with 12
+func checkCY(number string) bool {
+	if strings.HasPrefix(number, "12") {
+		return false
+	}
+
+	// digits in odd positions are translated before they're added
+	translated := []int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21}
+	sum := 0
+	for i, d := range digits(number[:8]) {
+		if i%2 == 0 {
+			sum += translated[d]
+		} else {
+			sum += d
+		}
+	}
+	return rune('A'+sum%26) == rune(number[8])
+}
+
+// checkCZ validates Czech VAT IDs, the 8 digit number of a legal entity, the 9 digit number of a special taxpayer starting with 6
+// or the birth number of an individual. Birth numbers of 9 digits were issued before 1954 without a check digit
+func checkCZ(number string) bool {
+	d := digits(number)
+	switch {
+	case len(d) == 8:
+		if d[0] == 9 {
+			return false
+		}
+		check := (11 - weightedSum(number, 8, 7, 6, 5, 4, 3, 2)%11) % 11
+		if check == 0 {
+			check = 1
+		}
+		return check%10 == d[7]
+	case len(d) == 9 && d[0] == 6:
+		check := weightedSum(number[1:], 8, 7, 6, 5, 4, 3, 2) % 11
+		return (8-(10-check)%11)%10 == d[8]
+	case len(d) == 9:
+		year, _ := strconv.Atoi(number[:2])
+		return year < 54
+	default:
+		value, _ := strconv.ParseInt(number, 10, 64)
+		base, _ := strconv.ParseInt(number[:9], 10, 64)
+		return value%11 == 0 || (base%11 == 10 && d[9] == 0)
+	}
+}
+
+// checkDK validates Danish VAT IDs, the weighted sum of all digits is divisible by 11
+func checkDK(number string) bool {
+	return weightedSum(number, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0
+}
+
+// checkEE validates Estonian VAT IDs
+func checkEE(number string) bool {
+	sum := weightedSum(number, 3, 7, 1, 3, 7, 1, 3, 7)
+	return (10-sum%10)%10 == digits(number)[8]
+}
+
+// checkEL validates Greek VAT IDs
+func checkEL(number string) bool {
+	sum := weightedSum(number, 256, 128, 64, 32, 16, 8, 4, 2)
+	return sum%11%10 == digits(number)[8]
+}
+
+// checkES validates Spanish VAT IDs: the DNI of citizens and the NIE of foreigners end with a check letter,
+// the CIF of legal entities ends with a check digit or the letter of that digit
+func checkES(number string) bool {
+	const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+	switch {
+	case number[0] >= '0' && number[0] <= '9':
+		value, err := strconv.Atoi(number[:8])
+		return err == nil && dniLetters[value%23] == number[8]
+	case strings.ContainsRune("KLM", rune(number[0])):
+		value, err := strconv.Atoi(number[1:8])
+		return err == nil && dniLetters[value%23] == number[8]
+	case strings.ContainsRune("XYZ", rune(number[0])):
+		value, err := strconv.Atoi(strconv.Itoa(strings.IndexByte("XYZ", number[0])) + number[1:8])
+		return err == nil && dniLetters[value%23] == number[8]
+	case strings.ContainsRune("ABCDEFGHJNPQRSUVW", rune(number[0])):
+		// the check digit is the Luhn check digit of the 7 digits
+		check := 0
+		for i, r := range number[1:8] {
+			digit := int(r - '0')
+			if i%2 == 0 {
+				digit *= 2
+				digit = digit/10 + digit%10
+			}
+			check += digit
+		}
+		check = (10 - check%10) % 10
+		return number[8] == byte('0'+check) || number[8] == "JABCDEFGHI"[check]
+	}
+	return false
+}
+
+// checkFI validates Finnish VAT IDs
+func checkFI(number string) bool {
+	remainder := weightedSum(number, 7, 9, 10, 5, 8, 4, 2) % 11
+	switch remainder {
+	case 0:
+		return digits(number)[7] == 0
+	case 1:
+		return false
+	default:
+		return 11-remainder == digits(number)[7]
+	}
+}
+
+// checkFR validates French VAT IDs with a numeric key, the key is calculated from the SIREN number.
+// Keys with letters are validated by their format only
+func checkFR(number string) bool {
+	key, err := strconv.Atoi(number[:2])
+	if err != nil {
+		return true
+	}
+	siren, _ := strconv.Atoi(number[2:])
+	return (12+3*(siren%97))%97 == key
+}
+
+// checkHU validates Hungarian VAT IDs, the weighted sum of all digits is divisible by 10
+func checkHU(number string) bool {
+	return weightedSum(number, 9, 7, 3, 1, 9, 7, 3, 1)%10 == 0
+}
+
+// checkIE validates Irish VAT IDs with a check letter, both the current format of 7 digits followed by the check letter
+// and an optional letter, and the old format with a letter or symbol in the second position
+func checkIE(number string) bool {
+	const letters = "WABCDEFGHIJKLMNOPQRSTUV"
+
+	calculate := func(base string, extra string) byte {
+		sum := weightedSum(base, 8, 7, 6, 5, 4, 3, 2)
+		if extra != "" {
+			sum += 9 * strings.Index(letters, extra)
+		}
+		return letters[sum%23]
+	}
+
+	if _, err := strconv.Atoi(number[:7]); err == nil {
+		return calculate(number[:7], number[8:]) == number[7]
+	}
+
+	// the old format is converted to the digits of the current format
+	return calculate("0"+number[2:7]+number[:1], "") == number[7]
+}
+
+// checkLT validates Lithuanian VAT IDs, the 9 digit number of a legal entity or the 12 digit number of a temporary taxpayer.
+// The digit before the last 2 digits is always 1
+func checkLT(number string) bool {
+	d := digits(number)
+	if d[len(d)-2] != 1 {
+		return false
+	}
+
+	base := d[:len(d)-1]
+	sum := 0
+	for i, digit := range base {
+		sum += (1 + i%9) * digit
+	}
+	check := sum % 11
+	if check == 10 {
+		sum = 0
+		for i, digit := range base {
+			sum += (1 + (i+2)%9) * digit
+		}
+		check = sum % 11
+	}
+	return check%10 == d[len(d)-1]
+}
+
+// checkLU validates Luxembourgish VAT IDs, the last 2 digits are the first 6 digits modulo 89
+func checkLU(number string) bool {
+	base, _ := strconv.Atoi(number[:6])
+	check, _ := strconv.Atoi(number[6:])
+	return base%89 == check
+}
+
+// checkLV validates Latvian VAT IDs, the number of a legal entity starts with a digit over 3 and is validated with a weighted sum,
+// the personal code of an individual starts with the date of birth. New personal codes starting with 32 have no check digit
+func checkLV(number string) bool {
+	d := digits(number)
+	if d[0] > 3 {
+		return weightedSum(number, 9, 1, 4, 8, 3, 10, 2, 5, 7, 6, 1)%11 == 3
+	}
+	if strings.HasPrefix(number, "32") {
+		return true
+	}
+
+	check := (1 + weightedSum(number, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9)) % 11 % 10
+	return check == d[10]
+}
+
+// checkMT validates Maltese VAT IDs, the weighted sum of all digits is divisible by 37. Numbers never start with 0
+func checkMT(number string) bool {
+	return number[0] != '0' && weightedSum(number, 3, 4, 6, 7, 8, 9, 10, 1)%37 == 0
+}
+
+// checkNL validates Dutch VAT IDs, either with the modulo 11 check of the RSIN number
+// or the modulo 97 check of the VAT IDs of sole proprietors
+func checkNL(number string) bool {
+	sum := weightedSum(number, 9, 8, 7, 6, 5, 4, 3, 2)
+	if sum%11 != 10 && sum%11 == digits(number)[8] {
+		return true
+	}
+
+	// letters are replaced by their positions in the alphabet plus 9, NL is 2321
+	value, ok := new(big.Int).SetString("2321"+strings.Replace(number, "B", "11", 1), 10)
+	return ok && new(big.Int).Mod(value, big.NewInt(97)).Int64() == 1
+}
+
+// checkPL validates Polish VAT IDs
+func checkPL(number string) bool {
+	sum := weightedSum(number, 6, 5, 7, 2, 3, 4, 5, 6, 7)
+	return sum%11 != 10 && sum%11 == digits(number)[9]
+}
+
+// checkPT validates Portuguese VAT IDs
+func checkPT(number string) bool {
+	check := 11 - weightedSum(number, 9, 8, 7, 6, 5, 4, 3, 2)%11
+	if check > 9 {
+		check = 0
+	}
+	return check == digits(number)[8]
+}
+
+// checkRO validates Romanian VAT IDs, the CUI number of 2 to 10 digits ends with a check digit. Numbers never start with 0
+func checkRO(number string) bool {
+	if number[0] == '0' {
+		return false
+	}
+
+	// the number without its check digit is padded to 9 digits
+	base := strings.Repeat("0", 10-len(number)) + number[:len(number)-1]
+	check := 10 * weightedSum(base, 7, 5, 3, 2, 1, 7, 5, 3, 2) % 11 % 10
+	return check == digits(number)[len(number)-1]
+}
+
+// checkSE validates Swedish VAT IDs, the organisation number is validated with the Luhn algorithm
+func checkSE(number string) bool {
+	return checkLuhn(number[:10])
+}
+
+// checkSI validates Slovenian VAT IDs. Numbers never start with 0
+func checkSI(number string) bool {
+	check := 11 - weightedSum(number, 8, 7, 6, 5, 4, 3, 2)%11
+	if check == 10 {
+		check = 0
+	}
+	return number[0] != '0' && check == digits(number)[7]
+}
+
+// checkSK validates Slovak VAT IDs, the number is divisible by 11
+func checkSK(number string) bool {
+	value, _ := strconv.ParseInt(number, 10, 64)
+	return value%11 == 0
+}
+
+// checkLuhn validates numbers with the Luhn algorithm, used by Italy and Sweden
+func checkLuhn(number string) bool {
+	d := digits(number)
+	sum := 0
+	for i := len(d) - 1; i >= 0; i-- {
+		digit := d[i]
+		if (len(d)-1-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,106 @@
+package vat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+)
+
+// ReverseChargeNote is added to results of B2B sales where the buyer accounts for the VAT
+const ReverseChargeNote = "Reverse charge: VAT to be accounted for by the recipient (Article 196, Directive 2006/112/EC)"
+
+// ErrUnknownCountry is returned when a country is not an EU member state
+var ErrUnknownCountry = errors.New("unknown EU country")
+
+// Sale describes the parties of a sale that decide how it is taxed with VAT
+type Sale struct {
+	SellerCountry string
+	BuyerCountry  string
+	// BuyerVATID is the VAT identification number of a business buyer, empty for consumers
+	BuyerVATID string
+}
+
+// Decision is the VAT treatment of a sale
+type Decision struct {
+	Country       string
+	Rate          utils.Percentage
+	ReverseCharge bool
+}
+
+// Rules decides the VAT treatment of EU sales from the VAT rates of the member states
+type Rules struct {
+	rates map[string]utils.Percentage
+}
+
+// NewRules constructor function for VAT rules with the VAT rates by country code. Rates outside of 0 to 100 percent are limited to that range
+func NewRules(rates map[string]utils.Percentage) *Rules {
+	r := &Rules{
+		rates: make(map[string]utils.Percentage, len(rates)),
+	}
+
+	for country, rate := range rates {
+		r.rates[normalizeCountry(country)] = rate.Clamp()
+	}
+
+	return r
+}
+
+// NewStandardRules returns VAT rules with the standard VAT rates of the EU member states
+func NewStandardRules() *Rules {
+	return NewRules(StandardRates())
+}
+
+// Decide returns the VAT treatment of a sale:
+// domestic sales are taxed with the VAT of the seller country,
+// cross-border sales to businesses with a valid VAT ID are reverse charged with 0% VAT,
+// and cross-border sales to consumers are taxed with the VAT of the buyer country (OSS).
+// Returns an error if a country is not an EU member state or the buyer VAT ID is invalid
+func (r *Rules) Decide(sale Sale) (Decision, error) {
+	seller := normalizeCountry(sale.SellerCountry)
+	buyer := normalizeCountry(sale.BuyerCountry)
+
+	sellerRate, ok := r.rates[seller]
+	if !ok {
+		return Decision{}, fmt.Errorf("%w: seller country %q", ErrUnknownCountry, sale.SellerCountry)
+	}
+
+	buyerRate, ok := r.rates[buyer]
+	if !ok {
+		return Decision{}, fmt.Errorf("%w: buyer country %q", ErrUnknownCountry, sale.BuyerCountry)
+	}
+
+	if seller == buyer {
+		return Decision{Country: seller, Rate: sellerRate}, nil
+	}
+
+	if sale.BuyerVATID != "" {
+		country, err := ValidateVATID(sale.BuyerVATID)
+		if err != nil {
+			return Decision{}, err
+		}
+		if country != buyer {
+			return Decision{}, fmt.Errorf("%w: %q is not a VAT ID of %v", ErrInvalidVATID, sale.BuyerVATID, buyer)
+		}
+
+		return Decision{Country: buyer, ReverseCharge: true}, nil
+	}
+
+	return Decision{Country: buyer, Rate: buyerRate}, nil
+}
+
+// Taxes returns the VAT of the decision as a tax, named after the country it is paid to
+func (d Decision) Taxes() []models.Tax {
+	return []models.Tax{*models.NewSimpleTax(fmt.Sprintf("VAT (%v)", d.Country), d.Rate)}
+}
+
+// normalizeCountry returns the VAT country code of a country, Greece uses EL instead of its ISO code GR
+func normalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "GR" {
+		return "EL"
+	}
+	return country
+}
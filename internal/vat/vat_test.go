@@ -0,0 +1,181 @@
+package vat
+
+import (
+	"testing"
+
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecide(t *testing.T) {
+	rules := NewStandardRules()
+
+	t.Run("DECIDE_DOMESTIC", func(t *testing.T) {
+		// Arrange
+		sale := Sale{SellerCountry: "DE", BuyerCountry: "DE", BuyerVATID: "DE136695976"}
+		expectedResult := Decision{Country: "DE", Rate: utils.WholePercentage(19)}
+
+		// Act
+		res, err := rules.Decide(sale)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("DECIDE_B2C_DESTINATION_VAT", func(t *testing.T) {
+		// Arrange
+		sale := Sale{SellerCountry: "DE", BuyerCountry: "fr"}
+		expectedResult := Decision{Country: "FR", Rate: utils.WholePercentage(20)}
+
+		// Act
+		res, err := rules.Decide(sale)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("DECIDE_B2B_REVERSE_CHARGE", func(t *testing.T) {
+		// Arrange
+		sale := Sale{SellerCountry: "DE", BuyerCountry: "AT", BuyerVATID: "ATU10223006"}
+		expectedResult := Decision{Country: "AT", ReverseCharge: true}
+
+		// Act
+		res, err := rules.Decide(sale)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, res)
+	})
+
+	t.Run("DECIDE_GREECE_ISO_CODE", func(t *testing.T) {
+		// Arrange
+		sale := Sale{SellerCountry: "DE", BuyerCountry: "GR"}
+
+		// Act
+		res, err := rules.Decide(sale)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "EL", res.Country)
+	})
+
+	t.Run("DECIDE_VAT_ID_OF_OTHER_COUNTRY", func(t *testing.T) {
+		// Arrange
+		sale := Sale{SellerCountry: "DE", BuyerCountry: "FR", BuyerVATID: "ATU10223006"}
+
+		// Act
+		_, err := rules.Decide(sale)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidVATID)
+	})
+
+	t.Run("DECIDE_UNKNOWN_COUNTRY", func(t *testing.T) {
+		// Arrange
+		sale := Sale{SellerCountry: "DE", BuyerCountry: "US"}
+
+		// Act
+		_, err := rules.Decide(sale)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownCountry)
+	})
+}
+
+func TestValidateVATID(t *testing.T) {
+	t.Run("VALIDATE_VAT_ID_VALID", func(t *testing.T) {
+		// Arrange
+		validIDs := []string{
+			"ATU10223006",
+			"BE0411905847",
+			"DE136695976",
+			"DK13585628",
+			"EE100931558",
+			"EL094259216",
+			"FI20774740",
+			"FR40303265045",
+			"HR38192148118",
+			"IT00743110157",
+			"LU15027442",
+			"NL004495445B01",
+			"PL5260250274",
+			"PT501964843",
+			"SE556036079301",
+			"SK2022749619",
+			"ESA12345674",
+			"BG175074752",
+			"CY10259033P",
+			"CZ25123891",
+			"ES54362315K",
+			"HU12892312",
+			"IE6433435F",
+			"LT119511515",
+			"LV40003521600",
+			"MT11679112",
+			"RO18547290",
+			"SI50223054",
+		}
+
+		for _, id := range validIDs {
+			// Act
+			_, err := ValidateVATID(id)
+
+			// Assert
+			assert.NoError(t, err, id)
+		}
+	})
+
+	t.Run("VALIDATE_VAT_ID_NORMALIZED", func(t *testing.T) {
+		// Act
+		res, err := ValidateVATID("de 136.695-976")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "DE", res)
+	})
+
+	t.Run("VALIDATE_VAT_ID_INVALID_CHECK_DIGIT", func(t *testing.T) {
+		// Arrange
+		invalidIDs := []string{
+			"DE136695977",
+			"ATU10223007",
+			"IT00743110158",
+			"NL004495446B01",
+			"FR41303265045",
+			"BG175074751",
+			"CY10259033Z",
+			"CZ25123890",
+			"ESA13585626",
+			"HU12892313",
+			"IE6433435E",
+			"LT100001919018",
+			"LV40003521601",
+			"MT11679113",
+			"RO18547291",
+			"SI50223055",
+		}
+
+		for _, id := range invalidIDs {
+			// Act
+			_, err := ValidateVATID(id)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidVATID, id)
+		}
+	})
+
+	t.Run("VALIDATE_VAT_ID_INVALID_FORMAT", func(t *testing.T) {
+		// Arrange
+		invalidIDs := []string{"", "DE", "DE12345678", "US123456789", "ATX10223006"}
+
+		for _, id := range invalidIDs {
+			// Act
+			_, err := ValidateVATID(id)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidVATID, id)
+		}
+	})
+}
@@ -1,99 +1,611 @@
 package calculator
 
 import (
+	"time"
+
 	"github.com/radoslavboychev/price-calculator-kata/internal/cap"
+	"github.com/radoslavboychev/price-calculator-kata/internal/clock"
 	"github.com/radoslavboychev/price-calculator-kata/internal/combining"
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/exemption"
+	"github.com/radoslavboychev/price-calculator-kata/internal/jurisdiction"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
 	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
 	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+	"github.com/radoslavboychev/price-calculator-kata/internal/vat"
 	"github.com/radoslavboychev/price-calculator-kata/pkg/result"
 )
 
+// grossPrecision is the amount of decimal places the multiplier for back-calculating net prices is calculated with
+const grossPrecision = 8
+
 // calculator struct that will store the types needed for performing calculations
 type calculator struct {
-	tax         models.Tax
-	discount    models.Discount
-	combineType combining.CombType
-	cap         cap.DiscountCap
+	tax             models.Tax
+	discount        models.Discount
+	combineType     combining.CombType
+	cap             cap.DiscountCap
+	rounding        models.Rounding
+	converter       *currency.Converter
+	displayCurrency *currency.CurrencyCode
+	asOf            time.Time
+	transactionAt   time.Time
+	clock           clock.Clock
+	cashRounding    *models.CashRounding
+	taxRates        *models.TaxRates
+	taxSchedule     *models.TaxSchedule
+	taxes           []models.Tax
+	pricing         models.PricingMode
+	jurisdictions   *jurisdiction.Resolver
+	destination     string
+	vatRules        *vat.Rules
+	sale            vat.Sale
+	vatDecision     vat.Decision
+	certificates    []exemption.Certificate
 }
 
 // NewCalculator constructor returns a new calculator and initializes the values
 func NewCalculator(tax models.Tax, discount models.Discount, combineType combining.CombType, discountCap cap.DiscountCap) *calculator {
+	// the calculated amounts are stored on the rules, so the calculator keeps its own copy of them
+	discount.Rules = append([]models.DiscountRule(nil), discount.Rules...)
 
 	return &calculator{
 		tax:         tax,
 		discount:    discount,
 		combineType: combineType,
 		cap:         discountCap,
+		clock:       clock.System(),
 	}
 }
 
+// WithRounding sets the rounding modes used when the final lines of a calculation are rounded. Rounds half-up by default
+func (c *calculator) WithRounding(rounding models.Rounding) *calculator {
+	c.rounding = rounding
+	return c
+}
+
+// WithConversion sets the display currency the results are also reported in and the converter used to convert them.
+// All lines are converted with 4 decimal precision before they're rounded
+func (c *calculator) WithConversion(converter *currency.Converter, to currency.CurrencyCode) *calculator {
+	c.converter = converter
+	c.displayCurrency = &to
+	return c
+}
+
+// WithAsOf sets the time the exchange rates are taken at, so old orders can be converted with the rates that were valid on the order date.
+// Uses the rates effective at the current time of the clock by default
+func (c *calculator) WithAsOf(at time.Time) *calculator {
+	c.asOf = at
+	return c
+}
+
+// WithTransactionTime sets the time of the transaction, so historical and future-dated orders are taxed with the rates in force
+// at that time, only certificates that are valid at that time exempt them from tax and only promotions active at that time are discounted.
+// Uses the current time of the clock by default
+func (c *calculator) WithTransactionTime(at time.Time) *calculator {
+	c.transactionAt = at
+	return c
+}
+
+// WithClock sets the clock that tells the time of transactions without a transaction time set, so tests can run at a fixed time.
+// Uses the system clock by default
+func (c *calculator) WithClock(clk clock.Clock) *calculator {
+	c.clock = clk
+	return c
+}
+
+// WithTaxRates sets the table of tax rates by product tax category. Without a table every product is taxed with the flat tax rate
+func (c *calculator) WithTaxRates(rates *models.TaxRates) *calculator {
+	c.taxRates = rates
+	return c
+}
+
+// WithTaxSchedule sets the schedule of tax rates by product tax category, which replaces the table of tax rates and the flat tax rate.
+// Products are taxed with the rate of their category in force at the time of the transaction
+func (c *calculator) WithTaxSchedule(schedule *models.TaxSchedule) *calculator {
+	c.taxSchedule = schedule
+	return c
+}
+
+// WithTaxes sets an ordered list of taxes that replaces the flat tax, e.g. GST followed by a compound QST.
+// Simple taxes are calculated on the taxable price, compound taxes on the taxable price plus the taxes before them
+func (c *calculator) WithTaxes(taxes ...models.Tax) *calculator {
+	c.taxes = taxes
+	return c
+}
+
+// WithJurisdiction sets the jurisdiction rate table and the destination ZIP code or jurisdiction code the products are shipped to.
+// Products are then taxed by every jurisdiction of the destination instead of the flat tax or the list of taxes
+func (c *calculator) WithJurisdiction(resolver *jurisdiction.Resolver, destination string) *calculator {
+	c.jurisdictions = resolver
+	c.destination = destination
+	return c
+}
+
+// WithVAT sets the EU VAT rules and the parties of the sale. Products are then taxed with the VAT the rules decide on
+// instead of the flat tax, the list of taxes or jurisdiction taxes. Reverse charged sales get a note on the result
+func (c *calculator) WithVAT(rules *vat.Rules, sale vat.Sale) *calculator {
+	c.vatRules = rules
+	c.sale = sale
+	return c
+}
+
+// WithExemptions sets the tax exemption certificates of the customer. Products in the scope of a valid certificate are taxed
+// with their rates reduced by the exempt portion, and the result records the reason of the exemption
+func (c *calculator) WithExemptions(certificates ...exemption.Certificate) *calculator {
+	c.certificates = certificates
+	return c
+}
+
+// WithPricingMode sets if product prices are net or include tax. Gross prices are split into the net price and the tax
+// portion, and the net price is then discounted and taxed as usual. Prices are net by default
+func (c *calculator) WithPricingMode(mode models.PricingMode) *calculator {
+	c.pricing = mode
+	return c
+}
+
+// WithCashRounding sets the cash rounding policy applied to the total price, the adjustment is reported as its own line.
+// Totals are not cash rounded by default
+func (c *calculator) WithCashRounding(rounding models.CashRounding) *calculator {
+	c.cashRounding = &rounding
+	return c
+}
+
 // Calculate runs the calculations for a specific product depending on the various conditions that could be met, and reports the results.
-func (c *calculator) Calculate(p *models.Product) *result.Result {
+// All amounts are calculated with 4 decimal precision and every final line is rounded to the minor units of the currency.
+// Credit products have a negative price, so every line of their result is a credit.
+// Returns an error if any of the amounts are not in the currency of the product price
+func (c *calculator) Calculate(p *models.Product) (*result.Result, error) {
 	startingPrice := p.Price()
-	productPrice := p.Price()
 
-	switch c.discount.TakesPrecedence {
-	case 1:
-		c.discount.UniversalDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.UniversalDiscount.Rate(), productPrice.Value)
-		c.tax.Amount.Value = utils.AmountFromPercentage(c.tax.Rate(), productPrice.Value-c.discount.UniversalDiscount.Amount.Value)
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), productPrice.Value-c.discount.UniversalDiscount.Amount.Value)
+	// gross prices are back-calculated to the net price before anything else is calculated
+	grossPrice := startingPrice
+	if c.pricing == models.PricingGross {
+		var err error
+		startingPrice, err = c.netPrice(p, grossPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// reset the amounts from previous calculations
+	zero := models.Money{Currency: startingPrice.Currency}
+	c.vatDecision = vat.Decision{}
+	c.tax.Amount = zero
+	for i := range c.discount.Rules {
+		c.discount.Rules[i].Amount = zero
+	}
+
+	// expenses of credit products are calculated on the refunded amount and credited with it
+	expensePrice := startingPrice
+	if p.IsCredit() {
+		expensePrice = startingPrice.Abs()
+	}
+
+	expenses, err := p.Cost().ExpenseLines(expensePrice)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsCredit() {
+		for i := range expenses {
+			expenses[i].Amount = expenses[i].Amount.Neg()
+		}
+	}
+
+	// discountable expenses are discounted together with the price
+	discountBase, err := sumExpenses(startingPrice, expenses, func(e models.ExpenseLine) bool { return e.Discountable })
+	if err != nil {
+		return nil, err
+	}
+
+	// discounts target products without a tax category by the default category they're taxed in
+	target := *p
+	if target.TaxCategory() == "" {
+		target = target.WithTaxCategory(c.taxCategory(p))
+	}
+
+	// discounts that take precedence are deducted from the price before it gets taxed
+	beforeTax, afterTax := c.discount.Applicable(&target, c.transactionTime())
+
+	beforeTaxDiscount, err := c.applyDiscounts(discountBase, beforeTax)
+	if err != nil {
+		return nil, err
+	}
+
+	discounted, err := discountBase.Sub(beforeTaxDiscount)
+	if err != nil {
+		return nil, err
+	}
+
+	taxBase, expenseBase, err := taxBases(startingPrice, discountBase, discounted, expenses)
+	if err != nil {
+		return nil, err
+	}
+
+	taxes, err := c.calculateTaxes(p, taxBase)
+	if err != nil {
+		return nil, err
+	}
+
+	c.tax.Amount, err = sumTaxes(zero, taxes)
+	if err != nil {
+		return nil, err
+	}
+
+	// the part of the tax that is attributable to taxable expenses
+	expenseTax := zero
+	if !taxBase.IsZero() {
+		expenseTax = c.tax.Amount.MulRate(expenseBase.Amount, taxBase.Amount)
+	}
+
+	// discounts that don't take precedence are calculated on the price left after the discounts before tax
+	afterTaxDiscount, err := c.applyDiscounts(discounted, afterTax)
+	if err != nil {
+		return nil, err
+	}
+
+	sumDiscount, err := beforeTaxDiscount.Add(afterTaxDiscount)
+	if err != nil {
+		return nil, err
+	}
+
+	sumDiscount, err = c.cap.CalculateCap(discountBase, sumDiscount)
+	if err != nil {
+		return nil, err
+	}
+
+	costs, err := calculateCosts(p.Cost(), expensePrice)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsCredit() {
+		costs = costs.Neg()
+	}
+
+	productPrice, err := sumMoney(startingPrice, c.tax.Amount, sumDiscount.Neg(), costs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newResult(startingPrice, taxes, sumDiscount, costs, productPrice, expenses, c.rounding, func(m models.Money) models.Money { return m })
+	res.WithExpenseTax(expenseTax.RoundToMinorUnits(c.rounding.Tax))
+	if c.pricing == models.PricingGross {
+		res.WithGrossPrice(grossPrice.RoundToMinorUnits(c.rounding.Total))
+	}
+
+	// every line is converted into the display currency before it gets rounded
+	if c.displayCurrency != nil {
+		rate, err := c.conversionRate(startingPrice.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		// the first line that can not be converted fails the calculation
+		var convertErr error
+		convert := func(m models.Money) models.Money {
+			converted, err := m.Convert(*c.displayCurrency, rate)
+			if err != nil && convertErr == nil {
+				convertErr = err
+			}
+			return converted
+		}
+
+		display := newResult(startingPrice, taxes, sumDiscount, costs, productPrice, expenses, c.rounding, convert)
+		display.WithExpenseTax(convert(expenseTax).RoundToMinorUnits(c.rounding.Tax))
+		if c.pricing == models.PricingGross {
+			display.WithGrossPrice(convert(grossPrice).RoundToMinorUnits(c.rounding.Total))
+		}
+		if convertErr != nil {
+			return nil, convertErr
+		}
+		res.WithDisplay(display, rate)
+	}
+
+	if c.cashRounding != nil {
+		res.RoundCash(*c.cashRounding)
+	}
+
+	if e, ok := c.exemption(p); ok {
+		res.WithExemption(e.Reason)
+	}
+
+	// the sale has already been decided on when the taxes were calculated
+	if c.vatDecision.ReverseCharge {
+		res.AddNote(vat.ReverseChargeNote)
+	}
+
+	if p.IsCredit() {
+		res.MarkCredit()
+	}
+
+	return res, nil
+}
+
+// calculateTaxes calculates every tax of the product on the taxable price in order
+func (c *calculator) calculateTaxes(p *models.Product, taxBase models.Money) ([]models.TaxLine, error) {
+	taxes, err := c.productTaxes(p)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.TaxLine, len(taxes))
+	previous := models.Money{Currency: taxBase.Currency}
+	for i, tax := range taxes {
+		base := taxBase
+		if tax.IsCompound() {
+			base, err = taxBase.Add(previous)
+			if err != nil {
+				return nil, err
+			}
 		}
-	case 2:
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), productPrice.Value)
+
+		amount := base.Percent(tax.Rate())
+		lines[i] = models.TaxLine{Description: tax.Name(), Amount: amount}
+
+		previous, err = previous.Add(amount)
+		if err != nil {
+			return nil, err
 		}
-		c.tax.Amount.Value = utils.AmountFromPercentage(c.tax.Rate(), productPrice.Value-c.discount.SpecialDiscount.Amount.Value)
-		c.discount.UniversalDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.UniversalDiscount.Rate(), productPrice.Value-c.discount.SpecialDiscount.Amount.Value)
+	}
+
+	return lines, nil
+}
+
+// productTaxes returns the taxes the product is taxed with, reduced by its tax exemption
+func (c *calculator) productTaxes(p *models.Product) ([]models.Tax, error) {
+	taxes, err := c.applicableTaxes(p)
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		c.tax.Amount.Value = utils.AmountFromPercentage(c.tax.Rate(), productPrice.Value)
-		c.discount.UniversalDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.UniversalDiscount.Rate(), productPrice.Value)
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), productPrice.Value)
+	if e, ok := c.exemption(p); ok {
+		return e.Apply(taxes), nil
+	}
+	return taxes, nil
+}
+
+// applicableTaxes returns the taxes that apply to the product. EU VAT is used first, then jurisdiction taxes of the destination,
+// then the list of taxes. Without any of them only the flat tax is used, with the rate of the product's tax category in force.
+// The VAT decision is kept for the notes of the result
+func (c *calculator) applicableTaxes(p *models.Product) ([]models.Tax, error) {
+	if c.vatRules != nil {
+		decision, err := c.vatRules.Decide(c.sale)
+		if err != nil {
+			return nil, err
 		}
+		c.vatDecision = decision
+		return decision.Taxes(), nil
+	}
+	if c.jurisdictions != nil {
+		return c.jurisdictions.Taxes(c.destination)
+	}
+	if len(c.taxes) > 0 {
+		return c.taxes, nil
 	}
 
-	var sumDiscount float64
+	rate, err := c.taxRate(p)
+	if err != nil {
+		return nil, err
+	}
+	return []models.Tax{*models.NewSimpleTax(c.tax.Name(), rate)}, nil
+}
+
+// exemption returns the tax exemption of the product at the time of the transaction, false if it is taxed in full
+func (c *calculator) exemption(p *models.Product) (exemption.Exemption, bool) {
+	return exemption.Resolve(p, c.taxCategory(p), c.transactionTime(), c.certificates...)
+}
 
-	if c.combineType == combining.TypeAdditive {
-		sumDiscount = c.cap.CalculateCap(startingPrice, (c.discount.SpecialDiscount.Amount.Value + c.discount.UniversalDiscount.Amount.Value))
-		productPrice.Value = (startingPrice.Value + c.tax.Amount.Value) - sumDiscount
-	} else {
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), startingPrice.Value-c.discount.UniversalDiscount.Amount.Value)
+// taxCategory returns the tax category of the product, or the default category if the product has none
+func (c *calculator) taxCategory(p *models.Product) models.TaxCategory {
+	if p.TaxCategory() != "" {
+		return p.TaxCategory()
+	}
+	if c.taxSchedule != nil {
+		return c.taxSchedule.DefaultCategory()
+	}
+	if c.taxRates != nil {
+		return c.taxRates.DefaultCategory()
+	}
+	return models.CategoryStandard
+}
+
+// transactionTime returns the time of the transaction, or the current time of the clock if none is set
+func (c *calculator) transactionTime() time.Time {
+	if c.transactionAt.IsZero() {
+		return c.clock.Now()
+	}
+	return c.transactionAt
+}
+
+// netPrice back-calculates the net price from a price that includes all of the product's taxes
+func (c *calculator) netPrice(p *models.Product, gross models.Money) (models.Money, error) {
+	one := format.Pow10(grossPrecision)
+
+	productTaxes, err := c.productTaxes(p)
+	if err != nil {
+		return gross, err
+	}
+
+	// the multiplier turns a net price into the gross price, e.g. 1.21 for a 21% tax
+	var taxes int64
+	for _, tax := range productTaxes {
+		base := one
+		if tax.IsCompound() {
+			base += taxes
 		}
-		sumDiscount = c.cap.CalculateCap(startingPrice, c.discount.UniversalDiscount.Amount.Value+c.discount.SpecialDiscount.Amount.Value)
-		productPrice.Value = (startingPrice.Value + c.tax.Amount.Value) - sumDiscount
+		taxes += format.MulDivRound(base, int64(tax.Rate()), int64(utils.HundredPercent))
 	}
 
-	costs := calculateCosts(p.Cost(), startingPrice)
+	return gross.MulRate(one, one+taxes), nil
+}
 
-	productPrice.Value += costs
+// applyDiscounts calculates the discounts in order and returns their sum. Additive discounts are all calculated on the base,
+// multiplicative discounts are each calculated on the base left after the discounts before them.
+// Fixed discounts are always deducted from the base left after the discounts before them, so they never exceed it
+func (c *calculator) applyDiscounts(base models.Money, rules []*models.DiscountRule) (models.Money, error) {
+	remaining := base
+	for _, rule := range rules {
+		_, fixed := rule.FixedAmount()
 
-	resCurrency := p.Price().Currency
-	res := result.NewResult(
+		ruleBase := base
+		if fixed || c.combineType != combining.TypeAdditive {
+			ruleBase = remaining
+		}
 
-		models.NewMoney(resCurrency, format.ToDecimal(startingPrice.Value, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(c.tax.Amount.Value, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(sumDiscount, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(costs, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(productPrice.Value, 2)),
-		p.Cost(),
-	)
+		// nothing is left for a fixed discount once the discounts before it used up the base
+		if fixed && (remaining.Amount < 0) != (base.Amount < 0) {
+			ruleBase.Amount = 0
+		}
+
+		var err error
+		rule.Amount, err = rule.Calculate(ruleBase)
+		if err != nil {
+			return base, err
+		}
+
+		remaining, err = remaining.Sub(rule.Amount)
+		if err != nil {
+			return base, err
+		}
+	}
 
-	return res
+	return base.Sub(remaining)
+}
+
+// taxBases returns the tax base, made of the price and the taxable expenses, and the part of it that is made of taxable expenses.
+// Discountable parts of the tax base are reduced by the discounts that take precedence over tax in proportion to the discount base
+func taxBases(startingPrice, discountBase, discounted models.Money, expenses []models.ExpenseLine) (taxBase, expenseBase models.Money, err error) {
+	zero := models.Money{Currency: startingPrice.Currency}
+
+	discountedExpenses, err := sumExpenses(zero, expenses, func(e models.ExpenseLine) bool { return e.Taxable && e.Discountable })
+	if err != nil {
+		return zero, zero, err
+	}
+
+	otherExpenses, err := sumExpenses(zero, expenses, func(e models.ExpenseLine) bool { return e.Taxable && !e.Discountable })
+	if err != nil {
+		return zero, zero, err
+	}
+
+	price := startingPrice
+	if !discountBase.IsZero() {
+		price = startingPrice.MulRate(discounted.Amount, discountBase.Amount)
+		discountedExpenses = discountedExpenses.MulRate(discounted.Amount, discountBase.Amount)
+	}
+
+	expenseBase, err = discountedExpenses.Add(otherExpenses)
+	if err != nil {
+		return zero, zero, err
+	}
+
+	taxBase, err = price.Add(expenseBase)
+	return taxBase, expenseBase, err
+}
+
+// sumExpenses adds the amounts of the expense lines that match the filter to the first amount
+func sumExpenses(first models.Money, expenses []models.ExpenseLine, filter func(models.ExpenseLine) bool) (models.Money, error) {
+	var amounts []models.Money
+	for _, e := range expenses {
+		if filter(e) {
+			amounts = append(amounts, e.Amount)
+		}
+	}
+	return sumMoney(first, amounts...)
+}
+
+// sumTaxes adds up the amounts of all tax lines
+func sumTaxes(zero models.Money, taxes []models.TaxLine) (models.Money, error) {
+	amounts := make([]models.Money, len(taxes))
+	for i, t := range taxes {
+		amounts[i] = t.Amount
+	}
+	return sumMoney(zero, amounts...)
+}
+
+// taxRate returns the tax rate of the product's tax category, in force at the time of the transaction if there is a tax schedule.
+// Returns the flat tax rate if there is neither a schedule nor a table of tax rates
+func (c *calculator) taxRate(p *models.Product) (utils.Percentage, error) {
+	if c.taxSchedule != nil {
+		return c.taxSchedule.RateAt(p.TaxCategory(), c.transactionTime())
+	}
+	if c.taxRates != nil {
+		return c.taxRates.Rate(p.TaxCategory()), nil
+	}
+	return c.tax.Rate(), nil
+}
+
+// newResult builds a result from the precise amounts, every line is mapped with the given function and then rounded to the minor units of its currency
+func newResult(startingPrice models.Money, taxes []models.TaxLine, discount, costs, total models.Money, expenses []models.ExpenseLine, rounding models.Rounding, mapper func(models.Money) models.Money) *result.Result {
+	final := func(m models.Money, mode format.RoundingMode) models.Money {
+		return mapper(m).RoundToMinorUnits(mode)
+	}
+
+	taxLines := make([]models.TaxLine, len(taxes))
+	for i, t := range taxes {
+		taxLines[i] = models.TaxLine{Description: t.Description, Amount: final(t.Amount, rounding.Tax)}
+	}
+
+	expenseLines := make([]models.ExpenseLine, len(expenses))
+	for i, e := range expenses {
+		expenseLines[i] = models.ExpenseLine{Description: e.Description, Amount: final(e.Amount, rounding.Expense), Taxable: e.Taxable, Discountable: e.Discountable}
+	}
+
+	return result.NewResult(
+		final(startingPrice, rounding.Total),
+		taxLines,
+		final(discount, rounding.Discount),
+		final(costs, rounding.Expense),
+		final(total, rounding.Total),
+		expenseLines,
+	)
 }
 
 // calculateCosts calculates and returns a sum of all expenses
-func calculateCosts(costs models.Costs, startingPrice models.Money) float64 {
-	var sum float64
+func calculateCosts(costs models.Costs, startingPrice models.Money) (models.Money, error) {
+	sum := models.Money{Currency: startingPrice.Currency}
 
 	for _, cost := range costs.Expenses {
-		val := cost.CalculateExpense(startingPrice)
+		val, err := cost.CalculateExpense(startingPrice)
+		if err != nil {
+			return sum, err
+		}
 
-		sum += val.Value
+		sum, err = sum.Add(val)
+		if err != nil {
+			return sum, err
+		}
+	}
+	return sum, nil
+}
+
+// conversionRate returns the exchange rate from the pricing currency to the display currency, effective at the as-of time
+// or at the current time of the clock if no as-of time is set
+func (c *calculator) conversionRate(from currency.CurrencyCode) (int64, error) {
+	if c.displayCurrency == nil || *c.displayCurrency == from {
+		return format.Pow10(currency.RatePrecision), nil
+	}
+
+	if c.converter == nil {
+		return 0, currency.ErrRateNotFound
+	}
+
+	at := c.asOf
+	if at.IsZero() {
+		at = c.clock.Now()
+	}
+
+	return c.converter.RateAt(from, *c.displayCurrency, at)
+}
+
+// sumMoney adds up all of the money amounts, returns an error if they're not all in the same currency
+func sumMoney(first models.Money, rest ...models.Money) (models.Money, error) {
+	sum := first
+	for _, m := range rest {
+		var err error
+		sum, err = sum.Add(m)
+		if err != nil {
+			return first, err
+		}
 	}
-	return format.ToDecimal(sum, 4)
+	return sum, nil
 }
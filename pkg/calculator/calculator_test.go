@@ -3,13 +3,18 @@ package calculator
 import (
 	"log"
 	"testing"
+	"time"
 
 	"github.com/radoslavboychev/price-calculator-kata/internal/cap"
+	"github.com/radoslavboychev/price-calculator-kata/internal/clock"
 	"github.com/radoslavboychev/price-calculator-kata/internal/combining"
 	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
+	"github.com/radoslavboychev/price-calculator-kata/internal/exemption"
+	"github.com/radoslavboychev/price-calculator-kata/internal/jurisdiction"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
 	"github.com/radoslavboychev/price-calculator-kata/internal/utils"
 	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
+	"github.com/radoslavboychev/price-calculator-kata/internal/vat"
 	"github.com/radoslavboychev/price-calculator-kata/pkg/result"
 
 	"github.com/stretchr/testify/assert"
@@ -39,426 +44,1717 @@ func TestCalculate(t *testing.T) {
 		p := models.NewProduct("", 0, models.Money{}, models.NewCosts())
 
 		// Assert
-		assert.NotEmpty(t, p.Name(), p.Cost(), p.Price(), p.UPC(), p.Price().Value, p.Price().Currency)
+		assert.NotEmpty(t, p.Name(), p.Cost(), p.Price(), p.UPC(), p.Price().Amount, p.Price().Currency)
 	})
 
 	// Testing if the limits in tax rate and discount rates are calculated correctly if invalid amounts have been inserted initially (too high)
 	t.Run("TEST_CALCULATOR_LIMITS", func(t *testing.T) {
 
-		tax := *models.NewTax(500)
+		tax := *models.NewTax(utils.WholePercentage(500))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(300, models.Money{}),
-			*models.NewSpecialDiscount(0, 5000, models.Money{}),
+			*models.NewUniversalDiscount(utils.WholePercentage(300), models.Money{}),
+			*models.NewSpecialDiscount(0, utils.WholePercentage(5000), models.Money{}),
 			models.NoPrecedence,
 		)
 
 		// Arrange
-		var expectedTaxRate uint16 = 100
-		var expectedDiscountRate uint16 = 100
-		var expectedSpecialDiscountRate uint16 = 100
+		expectedTaxRate := utils.WholePercentage(100)
+		expectedDiscountRate := utils.WholePercentage(100)
+		expectedSpecialDiscountRate := utils.WholePercentage(100)
 
 		// Act
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Assert
 		assert.Equal(t, expectedTaxRate, calc.tax.Rate())
-		assert.Equal(t, expectedDiscountRate, calc.discount.UniversalDiscount.Rate())
-		assert.Equal(t, expectedSpecialDiscountRate, calc.discount.SpecialDiscount.Rate())
+		assert.Equal(t, expectedDiscountRate, calc.discount.Rules[0].Rate())
+		assert.Equal(t, expectedSpecialDiscountRate, calc.discount.Rules[1].Rate())
 	})
 
 	t.Run("TEST_CALCULATOR_NIL_VALUES", func(t *testing.T) {
 
-		tax := *models.NewTax(0)
-		discount := *models.NewDiscount(*models.NewUniversalDiscount(0, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
+		tax := *models.NewTax(percentage("0"))
+		discount := *models.NewDiscount(*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
 			models.NoPrecedence,
 		)
 
 		// Arrange
-		var expectedTaxRate uint16 = 0
-		var expectedDiscountRate uint16 = 0
-		var expectedSpecialDiscountRate uint16 = 0
+		expectedTaxRate := utils.WholePercentage(0)
+		expectedDiscountRate := utils.WholePercentage(0)
+		expectedSpecialDiscountRate := utils.WholePercentage(0)
 
 		// Act
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Assert
 		assert.Equal(t, expectedTaxRate, calc.tax.Rate())
-		assert.Equal(t, expectedDiscountRate, calc.discount.UniversalDiscount.Rate())
-		assert.Equal(t, expectedSpecialDiscountRate, calc.discount.SpecialDiscount.Rate())
+		assert.Equal(t, expectedDiscountRate, calc.discount.Rules[0].Rate())
+		assert.Equal(t, expectedSpecialDiscountRate, calc.discount.Rules[1].Rate())
 	})
 
 	// Testing the TAX requirement - tax calculation
 	t.Run("TEST_TAX_REQUIREMENT", func(t *testing.T) {
 
-		tax := *models.NewTax(20)
-		discount := models.Discount{
-			UniversalDiscount: *models.NewUniversalDiscount(0, models.Money{}),
-			SpecialDiscount:   *models.NewSpecialDiscount(0, 0, models.Money{}),
-			TakesPrecedence:   models.NoPrecedence,
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTotal := units("24.30")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
+
+	// Tests DISCOUNT requirement - calculating and applying discount
+	t.Run("TEST_DISCOUNT_REQUIREMENT", func(t *testing.T) {
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("4.05")
+		expectedDiscount := units("3.04")
+		expectedTotal := units("21.26")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+
+	})
+
+	// Tests REPORT requirement for printing different reports in different conditions
+	t.Run("TEST_REPORT_REQUIREMENT", func(t *testing.T) {
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		tax1 := *models.NewTax(percentage("20"))
+		discount1 := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		tax2 := *models.NewTax(percentage("20"))
+		discount2 := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		case1 := NewCalculator(tax1, discount1, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+		case2 := NewCalculator(tax2, discount2, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		resCase1, err := case1.Calculate(&p)
+		assert.NoError(t, err)
+		resCase2, err := case2.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Act
+		report1 := resCase1.Report()
+		report2 := resCase2.Report()
+
+		// Assert
+		assert.Contains(t, report1, "Discounts")
+		assert.NotContains(t, report2, "Discounts")
+
+	})
+
+	// Tests SELECTIVE requirement for special UPC discounts
+	t.Run("TEST_SELECTIVE_REQUIREMENT", func(t *testing.T) {
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("4.05")
+		expectedDiscounts := units("4.46")
+		expectedTotal := units("19.85")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
+
+	// Tests PRECEDENCE requirement for applying tax before or after specific discounts. Special discount applies before tax
+	t.Run("TEST_PRECEDENCE_REQUIREMENT", func(t *testing.T) {
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.PrecedenceSpecial,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedUPCDiscount := units("1.42")
+		expectedUniversalDiscount := units("2.82")
+		expectedTotalDiscount := expectedUPCDiscount + expectedUniversalDiscount
+		expectedFinalPrice := units("19.77")
+		expectedTax := units("3.77")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTotalDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedFinalPrice, res.TotalPrice().Amount)
+	})
+
+	// Tests the case where universal discount takes precedence over tax
+	t.Run("TEST_PRECEDENCE_REQUIREMENT_UNIVERSAL_DISCOUNT_TAKES_PRECEDENCE", func(t *testing.T) {
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.PrecedenceUniversal,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedUPCDiscount := units("1.42")
+		expectedUniversalDiscount := units("2.82")
+		expectedTotalDiscount := expectedUPCDiscount + expectedUniversalDiscount
+		expectedFinalPrice := units("19.45")
+		expectedTax := units("3.44")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTotalDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedFinalPrice, res.TotalPrice().Amount)
+	})
+
+	// Tests EXPENSE requirement where costs can be applied onto a price
+	t.Run("TEST_EXPENSE_REQUIREMENT", func(t *testing.T) {
+
+		// Arrange
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
+
+		costs := models.NewCosts(costAbsolute, costPercentage)
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("4.25")
+		expectedDiscount := units("4.46")
+		expectedTotal := units("22.45")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
+
+	// Tests COMBINING requirement where there are two different methods of combining discounts
+	t.Run("TEST_COMBINING_REQUIREMENT_ADDITIVE", func(t *testing.T) {
+
+		// Arrange
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
+
+		costs := models.NewCosts(costAbsolute, costPercentage)
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
+
+		tax := *models.NewTax(percentage("21"))
+
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("4.25")
+		expectedDiscountsAdditive := units("4.46")
+		expectedTotalAdditive := units("22.45")
+
+		// Act
+		resAdditive, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, resAdditive.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsAdditive, resAdditive.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalAdditive, resAdditive.TotalPrice().Amount)
+	})
+
+	t.Run("TEST_COMBINING_REQUIREMENT_MULTIPLICATIVE", func(t *testing.T) {
+		// Arrange
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
+
+		costs := models.NewCosts(costAbsolute, costPercentage)
+
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
+
+		tax := *models.NewTax(percentage("21"))
+
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("4.25")
+		expectedDiscountsMultiplicative := units("4.24")
+		expectedTotalMultiplicative := units("22.66")
+
+		// Act
+		resMultiplicative, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, resMultiplicative.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsMultiplicative, resMultiplicative.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalMultiplicative, resMultiplicative.TotalPrice().Amount)
+	})
+
+	// Tests CURRENCY requirement where different currencies can be used. Checking default case for USD
+	t.Run("TEST_CURRENCY_USD", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("4.05")
+		expectedTotal := units("24.30")
+		expectedCurrencyTax := currency.USD
+		expectedCurrencyTotal := currency.USD
+		expectedCurrencyStarting := currency.USD
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedCurrencyTotal, res.TotalPrice().Currency)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Equal(t, expectedCurrencyStarting, res.StartingPrice().Currency)
+		assert.Equal(t, expectedCurrencyTax, res.TaxAmount().Currency)
+
+	})
+
+	// Tests CURRENCY requirement where different currencies can be used. Checking default case for GBP
+	t.Run("TEST_CURRENCY_GBP", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(1, "17.76"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("3.55")
+		expectedTotal := units("21.31")
+		expectedCurrencyTax := currency.GBP
+		expectedCurrencyTotal := currency.GBP
+		expectedCurrencyStarting := currency.GBP
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedCurrencyTotal, res.TotalPrice().Currency)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Equal(t, expectedCurrencyStarting, res.StartingPrice().Currency)
+		assert.Equal(t, expectedCurrencyTax, res.TaxAmount().Currency)
+
+	})
+
+	// Tests currencies without minor units, all final lines are rounded to whole units
+	t.Run("TEST_CURRENCY_JPY", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.JPY, "1234"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("259")
+		expectedTotal := units("1493")
+		expectedReport := "TOTAL = 1493 JPY"
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, res.Report(), expectedReport)
+	})
+
+	// Tests currencies with three decimal minor units
+	t.Run("TEST_CURRENCY_KWD", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.KWD, "17.765"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedTax := units("3.553")
+		expectedTotal := units("21.318")
+		expectedReport := "TOTAL = 21.318 KWD"
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, res.Report(), expectedReport)
+	})
+
+	// Tests that tax, discount and total lines can each be rounded with their own rounding mode
+	t.Run("TEST_ROUNDING_MODES", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("10"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		rounding := models.NewRounding(format.HalfEven, format.Up, format.HalfUp, format.Down)
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithRounding(rounding)
+
+		// Arrange
+		// tax = 2.025, discount = 3.0375, total = 20.25 + 2.025 - 3.0375 = 19.2375
+		expectedTax := units("2.02")
+		expectedDiscount := units("3.04")
+		expectedTotal := units("19.23")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
+
+	// Tests that expenses in a different currency than the product price are rejected instead of summed up
+	t.Run("TEST_CURRENCY_MISMATCH", func(t *testing.T) {
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.GBP, "2.2"))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts(costAbsolute))
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+		assert.Nil(t, res)
+	})
+
+	// Tests that a credit product is priced with negative tax, discounts, expenses and total
+	t.Run("TEST_CREDIT_NOTE", func(t *testing.T) {
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
+
+		p := models.NewCreditProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts(costAbsolute, costPercentage))
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		expectedStartingPrice := units("-20.25")
+		expectedTax := units("-4.25")
+		expectedDiscount := units("-4.46")
+		expectedExpenses := units("-2.40")
+		expectedTotal := units("-22.45")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
+
+		// Assert
+		assert.True(t, res.IsCredit())
+		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedExpenses, res.TotalExpenses().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, report, "CREDIT NOTE")
+		assert.Contains(t, report, "TOTAL = -22.45 USD")
+	})
+
+	// Tests that the discount cap limits the size of discounts on credit products
+	t.Run("TEST_CREDIT_NOTE_CAP", func(t *testing.T) {
+		p := models.NewCreditProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
+			models.NoPrecedence,
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(1, "4", currency.USD))
+
+		// Arrange
+		expectedDiscount := units("-4.00")
+		expectedTotal := units("-20.50")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
+
+	// Tests that the result keeps the lines in the pricing currency and converts them into the display currency before they're rounded
+	t.Run("TEST_CURRENCY_CONVERSION", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		rate, _ := currency.NewExchangeRate(currency.EUR, currency.GBP, "0.8535", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+		converter := currency.NewConverter(currency.EUR, rate)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithConversion(converter, currency.GBP)
+
+		// Arrange
+		// tax = 4.05 * 0.8535 = 3.4567, discount = 3.0375 * 0.8535 = 2.5925, total = 21.2625 * 0.8535 = 18.1475
+		expectedTax := units("3.46")
+		expectedDiscount := units("2.59")
+		expectedTotal := units("18.15")
+		expectedOriginalTotal := units("21.26")
+		var expectedRate int64 = 85350000
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
+
+		// Assert
+		assert.Equal(t, currency.EUR, res.TotalPrice().Currency)
+		assert.Equal(t, expectedOriginalTotal, res.TotalPrice().Amount)
+		assert.Equal(t, expectedRate, res.Rate())
+		assert.Equal(t, currency.GBP, res.Display().TotalPrice().Currency)
+		assert.Equal(t, expectedTax, res.Display().TaxAmount().Amount)
+		assert.Equal(t, expectedDiscount, res.Display().TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.Display().TotalPrice().Amount)
+		assert.Contains(t, report, "TOTAL = 21.26 EUR (18.15 GBP)")
+		assert.Contains(t, report, "Rate = 1 EUR = 0.8535 GBP")
+	})
+
+	// Tests that the result lines are converted with the rate that was effective at the as-of time
+	t.Run("TEST_CURRENCY_CONVERSION_AS_OF", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		oldRate, _ := currency.NewExchangeRate(currency.EUR, currency.GBP, "0.8000", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		newRate, _ := currency.NewExchangeRate(currency.EUR, currency.GBP, "0.8535", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+		converter := currency.NewConverter(currency.EUR, newRate, oldRate)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithConversion(converter, currency.GBP).
+			WithAsOf(time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC))
+
+		// Arrange
+		// total = 24.30 * 0.80 = 19.44
+		expectedTotal := units("19.44")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res.Display().TotalPrice().Amount)
+	})
+
+	// Tests that rates dated after the current time of the clock are not used for the conversion
+	t.Run("TEST_CURRENCY_CONVERSION_FUTURE_RATE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		currentRate, _ := currency.NewExchangeRate(currency.EUR, currency.GBP, "0.8000", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		futureRate, _ := currency.NewExchangeRate(currency.EUR, currency.GBP, "0.8535", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+		converter := currency.NewConverter(currency.EUR, currentRate, futureRate)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithConversion(converter, currency.GBP).
+			WithClock(clock.Fixed(time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)))
+
+		// Arrange
+		// total = 24.30 * 0.80 = 19.44
+		expectedTotal := units("19.44")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTotal, res.Display().TotalPrice().Amount)
+	})
+
+	// Tests that the calculation fails when there's no rate to the display currency
+	t.Run("TEST_CURRENCY_CONVERSION_RATE_NOT_FOUND", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithConversion(currency.NewConverter(currency.EUR), currency.GBP)
+
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.ErrorIs(t, err, currency.ErrRateNotFound)
+		assert.Nil(t, res)
+	})
+
+	// Tests that cash rounding rounds only the total and reports the adjustment so the report reconciles
+	t.Run("TEST_CASH_ROUNDING", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.CHF, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithCashRounding(models.NewCurrencyCashRounding(format.HalfUp))
+
+		// Arrange
+		// total = 20.25 + 4.05 - 3.04 = 21.26, rounded to 0.05 CHF
+		expectedTax := units("4.05")
+		expectedDiscount := units("3.04")
+		expectedAdjustment := units("-0.01")
+		expectedTotal := units("21.25")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
+
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedAdjustment, res.CashRounding().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, report, "Cash rounding = -0.01 CHF")
+		assert.Contains(t, report, "TOTAL = 21.25 CHF")
+	})
+
+	// Tests that the tax rate is selected by the tax category of the product
+	t.Run("TEST_TAX_CATEGORIES", func(t *testing.T) {
+		book := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).WithTaxCategory(models.CategoryBooks)
+		food := models.NewProduct("Bread", 234567, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).WithTaxCategory(models.CategoryFood)
+		other := models.NewProduct("Lamp", 345678, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		rates, _ := models.NewTaxRates(models.CategoryStandard, map[models.TaxCategory]utils.Percentage{
+			models.CategoryStandard: percentage("20"),
+			models.CategoryBooks:    percentage("5"),
+			models.CategoryFood:     percentage("0"),
+		})
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithTaxRates(rates)
+
+		// Arrange
+		expectedBookTax := units("1.01")
+		expectedFoodTax := units("0")
+		expectedOtherTax := units("4.05")
+
+		// Act
+		resBook, errBook := calc.Calculate(&book)
+		resFood, errFood := calc.Calculate(&food)
+		resOther, errOther := calc.Calculate(&other)
+
+		// Assert
+		assert.NoError(t, errBook)
+		assert.NoError(t, errFood)
+		assert.NoError(t, errOther)
+		assert.Equal(t, expectedBookTax, resBook.TaxAmount().Amount)
+		assert.Equal(t, expectedFoodTax, resFood.TaxAmount().Amount)
+		assert.Equal(t, expectedOtherTax, resOther.TaxAmount().Amount)
+	})
+
+	// Tests that an ordered list of taxes is calculated with compound taxes on top of the taxes before them
+	t.Run("TEST_COMPOUND_TAXES", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithTaxes(*models.NewSimpleTax("GST", percentage("5")), *models.NewSimpleTax("PST", percentage("7")), *models.NewCompoundTax("Excise", percentage("10")))
+
+		// Arrange
+		// GST = 1.0125, PST = 1.4175, Excise = (20.25 + 1.0125 + 1.4175) * 10% = 2.268
+		expectedTaxes := []models.TaxLine{
+			{Description: "GST", Amount: models.NewMoney(currency.USD, "1.01")},
+			{Description: "PST", Amount: models.NewMoney(currency.USD, "1.42")},
+			{Description: "Excise", Amount: models.NewMoney(currency.USD, "2.27")},
+		}
+		expectedTax := units("4.70")
+		expectedTotal := units("24.95")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
+
+		// Assert
+		assert.Equal(t, expectedTaxes, res.Taxes())
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, report, "GST = 1.01 USD")
+		assert.Contains(t, report, "PST = 1.42 USD")
+		assert.Contains(t, report, "Excise = 2.27 USD")
+	})
+
+	// Tests that taxes are calculated on the price after the discounts that take precedence over tax
+	t.Run("TEST_COMPOUND_TAXES_PRECEDENCE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.00"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("10"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.PrecedenceUniversal)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithTaxes(*models.NewSimpleTax("GST", percentage("5")), *models.NewCompoundTax("QST", percentage("10")))
+
+		// Arrange
+		// taxable price = 18.00, GST = 0.90, QST = (18.00 + 0.90) * 10% = 1.89
+		expectedTaxes := []models.TaxLine{
+			{Description: "GST", Amount: models.NewMoney(currency.USD, "0.90")},
+			{Description: "QST", Amount: models.NewMoney(currency.USD, "1.89")},
 		}
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTaxes, res.Taxes())
+	})
+
+	// Tests that gross prices are split into the net price and the tax portion
+	t.Run("TEST_GROSS_PRICING", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithPricingMode(models.PricingGross)
+
+		// Arrange
+		// net = 20.25 / 1.21 = 16.7355, tax = 3.5145
+		expectedNet := units("16.74")
+		expectedTax := units("3.51")
+		expectedTotal := units("20.25")
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		gross, isGross := res.GrossPrice()
+		report := res.Report()
+
+		// Assert
+		assert.True(t, isGross)
+		assert.Equal(t, units("20.25"), gross.Amount)
+		assert.Equal(t, expectedNet, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, report, "Gross = 20.25 EUR")
+		assert.Contains(t, report, "Net = 16.74 EUR")
+		assert.Contains(t, report, "Tax = 3.51 EUR")
+		assert.NotContains(t, report, "Cost")
+	})
+
+	// Tests that discounts still take precedence over tax on the net price of gross prices
+	t.Run("TEST_GROSS_PRICING_PRECEDENCE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "24.20"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("10"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.PrecedenceUniversal)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithPricingMode(models.PricingGross)
 
 		// Arrange
-		expectedTotal := 24.30
+		// net = 20.00, discount = 2.00, tax = 18.00 * 21% = 3.78
+		expectedNet := units("20.00")
+		expectedDiscount := units("2.00")
+		expectedTax := units("3.78")
+		expectedTotal := units("21.78")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
 
 		// Assert
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedNet, res.StartingPrice().Amount)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 	})
 
-	// Tests DISCOUNT requirement - calculating and applying discount
-	t.Run("TEST_DISCOUNT_REQUIREMENT", func(t *testing.T) {
+	// Tests that gross prices with compound taxes are back-calculated with all of the taxes
+	t.Run("TEST_GROSS_PRICING_COMPOUND_TAXES", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.CAD, "23.10"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithTaxes(*models.NewSimpleTax("GST", percentage("5")), *models.NewCompoundTax("QST", percentage("10"))).
+			WithPricingMode(models.PricingGross)
+
+		// Arrange
+		// net = 23.10 / (1 + 0.05 + 1.05 * 0.10) = 20.00
+		expectedNet := units("20.00")
+		expectedTaxes := []models.TaxLine{
+			{Description: "GST", Amount: models.NewMoney(currency.CAD, "1.00")},
+			{Description: "QST", Amount: models.NewMoney(currency.CAD, "2.10")},
+		}
+
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		// Assert
+		assert.Equal(t, expectedNet, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTaxes, res.Taxes())
+	})
 
-		tax := *models.NewTax(20)
+	// Tests that tax and discount rates can have decimals
+	t.Run("TEST_FRACTIONAL_RATES", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("8.875"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
-			models.NoPrecedence,
+			*models.NewUniversalDiscount(percentage("12.5"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("2.25"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		// tax = 1.7971875, universal = 2.53125, special = 0.455625
+		expectedTaxPrecise := units("1.7972")
+		expectedTax := units("1.80")
+		expectedDiscount := units("2.99")
+		expectedTotal := units("19.06")
+
+		// Act
+		res, taxPrecise, _, _, _, _ := calc.calculatePrecision(&p)
+
+		// Assert
+		assert.Equal(t, expectedTaxPrecise, taxPrecise)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
+
+	// Tests that products shipped to a destination are taxed by every jurisdiction of the destination
+	t.Run("TEST_JURISDICTION_TAXES", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		resolver := jurisdiction.NewResolver(
+			jurisdiction.Rate{Key: "10001", Level: jurisdiction.LevelState, Name: "New York", Rate: percentage("4")},
+			jurisdiction.Rate{Key: "10001", Level: jurisdiction.LevelCity, Name: "New York City", Rate: percentage("4.5")},
+			jurisdiction.Rate{Key: "10001", Level: jurisdiction.LevelSpecial, Name: "MCTD", Rate: percentage("0.375")},
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithJurisdiction(resolver, "10001")
 
 		// Arrange
-		expectedTax := 4.05
-		expectedDiscount := 3.04
-		expectedTotal := 21.26
+		// state = 0.81, city = 0.91125, special = 0.0759375
+		expectedTaxes := []models.TaxLine{
+			{Description: "State Tax (New York)", Amount: models.NewMoney(currency.USD, "0.81")},
+			{Description: "City Tax (New York City)", Amount: models.NewMoney(currency.USD, "0.91")},
+			{Description: "Special District Tax (MCTD)", Amount: models.NewMoney(currency.USD, "0.08")},
+		}
+		expectedTotal := units("22.05")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
 
 		// Assert
-		assert.Equal(t, expectedDiscount, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedTaxes, res.Taxes())
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, report, "City Tax (New York City) = 0.91 USD")
+	})
+
+	// Tests that the calculation fails when there are no rates for the destination
+	t.Run("TEST_JURISDICTION_NOT_FOUND", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
 
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).WithJurisdiction(jurisdiction.NewResolver(), "10001")
+
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.ErrorIs(t, err, jurisdiction.ErrJurisdictionNotFound)
+		assert.Nil(t, res)
 	})
 
-	// Tests REPORT requirement for printing different reports in different conditions
-	t.Run("TEST_REPORT_REQUIREMENT", func(t *testing.T) {
+	// Tests that cross-border sales to consumers are taxed with the VAT of the buyer country
+	t.Run("TEST_VAT_DESTINATION", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithVAT(vat.NewStandardRules(), vat.Sale{SellerCountry: "DE", BuyerCountry: "FR"})
 
-		tax1 := *models.NewTax(20)
-		discount1 := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
-			models.NoPrecedence,
-		)
+		// Arrange
+		expectedTaxes := []models.TaxLine{{Description: "VAT (FR)", Amount: models.NewMoney(currency.EUR, "4.05")}}
+		expectedTotal := units("24.30")
 
-		tax2 := *models.NewTax(20)
-		discount2 := *models.NewDiscount(
-			*models.NewUniversalDiscount(0, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
-			models.NoPrecedence,
-		)
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, expectedTaxes, res.Taxes())
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Empty(t, res.Notes())
+	})
+
+	// Tests that cross-border sales to businesses are reverse charged with 0% VAT and a note
+	t.Run("TEST_VAT_REVERSE_CHARGE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
 
-		case1 := NewCalculator(tax1, discount1, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
-		case2 := NewCalculator(tax2, discount2, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithVAT(vat.NewStandardRules(), vat.Sale{SellerCountry: "DE", BuyerCountry: "AT", BuyerVATID: "ATU10223006"})
 
 		// Arrange
-		resCase1 := case1.Calculate(&p)
-		resCase2 := case2.Calculate(&p)
+		expectedTax := units("0")
+		expectedTotal := units("20.25")
 
 		// Act
-		report1 := resCase1.Report()
-		report2 := resCase2.Report()
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
 
 		// Assert
-		assert.Contains(t, report1, "Discounts")
-		assert.NotContains(t, report2, "Discounts")
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Equal(t, []string{vat.ReverseChargeNote}, res.Notes())
+		assert.Contains(t, report, "Note: Reverse charge")
+		assert.NotContains(t, report, "VAT (AT) =")
+	})
+
+	// Tests that sales to businesses with an invalid VAT ID are rejected
+	t.Run("TEST_VAT_INVALID_VAT_ID", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.EUR, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("21"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithVAT(vat.NewStandardRules(), vat.Sale{SellerCountry: "DE", BuyerCountry: "AT", BuyerVATID: "ATU10223007"})
+
+		// Act
+		res, err := calc.Calculate(&p)
 
+		// Assert
+		assert.ErrorIs(t, err, vat.ErrInvalidVATID)
+		assert.Nil(t, res)
 	})
 
-	// Tests SELECTIVE requirement for special UPC discounts
-	t.Run("TEST_SELECTIVE_REQUIREMENT", func(t *testing.T) {
+	// Tests that an ordered collection of discounts is applied, before-tax discounts first and only those targeting the product
+	t.Run("TEST_DISCOUNT_RULES", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(
+			*models.NewDiscountRule("Autumn sale", percentage("10")),
+			*models.NewDiscountRule("Book week", percentage("5")).WithUPCs(123456).WithBeforeTax(true),
+			*models.NewDiscountRule("Clearance", percentage("20")).WithUPCs(999999),
+			*models.NewDiscountRule("Loyalty", percentage("3")),
+			*models.NewDiscountRule("Newsletter", percentage("2")).WithBeforeTax(true),
+		)
+
+		calcAdditive := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+		calcMultiplicative := NewCalculator(tax, discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Arrange
+		// before tax: 20.25 * 7% = 1.4175, after tax: 18.8325 * 13% = 2.4482
+		expectedTaxAdditive := units("3.77")
+		expectedDiscountsAdditive := units("3.87")
+		expectedTotalAdditive := units("20.15")
+
+		// before tax: 20.25 * 5% = 1.0125, 19.2375 * 2% = 0.3848, after tax: 18.8527 * 10% = 1.8853, 16.9674 * 3% = 0.5090
+		expectedTaxMultiplicative := units("3.77")
+		expectedDiscountsMultiplicative := units("3.79")
+		expectedTotalMultiplicative := units("20.23")
+
+		// Act
+		resAdditive, errAdditive := calcAdditive.Calculate(&p)
+		resMultiplicative, errMultiplicative := calcMultiplicative.Calculate(&p)
+
+		// Assert
+		assert.NoError(t, errAdditive)
+		assert.NoError(t, errMultiplicative)
+		assert.Equal(t, expectedTaxAdditive, resAdditive.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsAdditive, resAdditive.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalAdditive, resAdditive.TotalPrice().Amount)
+		assert.Equal(t, expectedTaxMultiplicative, resMultiplicative.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsMultiplicative, resMultiplicative.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalMultiplicative, resMultiplicative.TotalPrice().Amount)
+		assert.True(t, discount.Rules[0].Amount.IsZero())
+	})
+
+	t.Run("TEST_PROMOTION_CLOCK", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		london, _ := time.LoadLocation("Europe/London")
+		discount := *models.NewDiscounts(
+			*models.NewDiscountRule("Happy hour", percentage("15")).WithWindow(models.PromotionWindow{
+				Days:      []time.Weekday{time.Friday},
+				DailyFrom: 17 * time.Hour,
+				DailyTo:   19 * time.Hour,
+				Location:  london,
+			}),
+		)
+
+		// Friday, 27 November 2026
+		happyHour := time.Date(2026, 11, 27, 18, 0, 0, 0, london)
+		closing := time.Date(2026, 11, 27, 20, 0, 0, 0, london)
+
+		// Arrange
+		// happy hour: 20.25 * 15% = 3.0375
+		expectedDiscountHappyHour := units("3.04")
+		expectedTotalHappyHour := units("21.26")
+		expectedTotalClosing := units("24.30")
+
+		// Act
+		resHappyHour, errHappyHour := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithClock(clock.Fixed(happyHour)).
+			Calculate(&p)
+		resClosing, errClosing := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithClock(clock.Fixed(happyHour)).
+			WithTransactionTime(closing).
+			Calculate(&p)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		// Assert
+		assert.NoError(t, errHappyHour)
+		assert.NoError(t, errClosing)
+		assert.Equal(t, expectedDiscountHappyHour, resHappyHour.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalHappyHour, resHappyHour.TotalPrice().Amount)
+		assert.True(t, resClosing.TotalDiscount().IsZero())
+		assert.Equal(t, expectedTotalClosing, resClosing.TotalPrice().Amount)
+	})
 
-		tax := *models.NewTax(20)
+	// Tests that universal and special discounts can both apply before tax, so the tax base is reduced by both of them
+	t.Run("TEST_PRECEDENCE_BOTH_DISCOUNTS_BEFORE_TAX", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}).WithBeforeTax(true),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}).WithBeforeTax(true),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calcAdditive := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+		calcMultiplicative := NewCalculator(tax, discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		expectedTax := 4.05
-		expectedDiscounts := 4.46
-		expectedTotal := 19.85
+		// additive: (20.25 - 4.455) * 20% = 3.159, multiplicative: (20.25 - 4.2424) * 20% = 3.2015
+		expectedTaxAdditive := units("3.16")
+		expectedDiscountsAdditive := units("4.46")
+		expectedTotalAdditive := units("18.95")
+		expectedTaxMultiplicative := units("3.20")
+		expectedDiscountsMultiplicative := units("4.24")
+		expectedTotalMultiplicative := units("19.21")
 
 		// Act
-		res := calc.Calculate(&p)
+		resAdditive, errAdditive := calcAdditive.Calculate(&p)
+		resMultiplicative, errMultiplicative := calcMultiplicative.Calculate(&p)
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.NoError(t, errAdditive)
+		assert.NoError(t, errMultiplicative)
+		assert.Equal(t, expectedTaxAdditive, resAdditive.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsAdditive, resAdditive.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalAdditive, resAdditive.TotalPrice().Amount)
+		assert.Equal(t, expectedTaxMultiplicative, resMultiplicative.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsMultiplicative, resMultiplicative.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalMultiplicative, resMultiplicative.TotalPrice().Amount)
 	})
 
-	// Tests PRECEDENCE requirement for applying tax before or after specific discounts. Special discount applies before tax
-	t.Run("TEST_PRECEDENCE_REQUIREMENT", func(t *testing.T) {
-
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+	// Tests that the global precedence moves a discount before tax without taking the precedence of the other one
+	t.Run("TEST_PRECEDENCE_KEEPS_DISCOUNT_FLAGS", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}).WithBeforeTax(true),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.PrecedenceSpecial,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		expectedUPCDiscount := 1.42
-		expectedUniversalDiscount := 2.82
-		expectedTotalDiscount := expectedUPCDiscount + expectedUniversalDiscount
-		expectedFinalPrice := 19.77
-		expectedTax := 3.77
+		// both discounts apply before tax: (20.25 - 4.455) * 20% = 3.159
+		expectedTax := units("3.16")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
 
 		// Assert
-		assert.Equal(t, expectedTotalDiscount, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedFinalPrice, res.TotalPrice().Value)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
 	})
 
-	// Tests the case where universal discount takes precedence over tax
-	t.Run("TEST_PRECEDENCE_REQUIREMENT_UNIVERSAL_DISCOUNT_TAKES_PRECEDENCE", func(t *testing.T) {
+	// Tests that targeted discounts apply to the products their matchers match
+	t.Run("TEST_DISCOUNT_RULES_TARGETING", func(t *testing.T) {
+		book := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).
+			WithTaxCategory(models.CategoryBooks).
+			WithTags("bestseller")
+		food := models.NewProduct("Chocolate", 76543210987, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).
+			WithTaxCategory(models.CategoryFood)
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(
+			*models.NewDiscountRule("Book week", percentage("5")).WithMatcher(models.MatchCategories(models.CategoryBooks)),
+			*models.NewDiscountRule("Bestsellers", percentage("3")).WithMatcher(models.MatchTags("bestseller")),
+			*models.NewDiscountRule("Manufacturer", percentage("10")).WithMatcher(models.MatchUPCPrefixes("0765")),
+		)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
-		tax := *models.NewTax(20)
-		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
-			models.PrecedenceUniversal,
+		// Arrange
+		expectedBookDiscounts := units("1.62")
+		expectedFoodDiscounts := units("2.03")
+
+		// Act
+		resBook, errBook := calc.Calculate(&book)
+		resFood, errFood := calc.Calculate(&food)
+
+		// Assert
+		assert.NoError(t, errBook)
+		assert.NoError(t, errFood)
+		assert.Equal(t, expectedBookDiscounts, resBook.TotalDiscount().Amount)
+		assert.Equal(t, expectedFoodDiscounts, resFood.TotalDiscount().Amount)
+	})
+
+	// Tests that products without a tax category are targeted by the default category they're taxed in
+	t.Run("TEST_DISCOUNT_RULES_TARGETING_DEFAULT_CATEGORY", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(
+			*models.NewDiscountRule("Book week", percentage("5")).WithMatcher(models.MatchCategories(models.CategoryBooks)),
+			*models.NewDiscountRule("Standard", percentage("10")).WithMatcher(models.MatchCategories(models.CategoryStandard)),
 		)
+		rates, _ := models.NewTaxRates(models.CategoryBooks, map[models.TaxCategory]utils.Percentage{models.CategoryBooks: percentage("5")})
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calcStandard := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+		calcBooks := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithTaxRates(rates)
 
 		// Arrange
-		expectedUPCDiscount := 1.42
-		expectedUniversalDiscount := 2.82
-		expectedTotalDiscount := expectedUPCDiscount + expectedUniversalDiscount
-		expectedFinalPrice := 19.45
-		expectedTax := 3.44
+		// standard: 20.25 * 10% = 2.025, books: 20.25 * 5% = 1.0125
+		expectedStandardDiscount := units("2.03")
+		expectedBooksDiscount := units("1.01")
+		expectedBooksTax := units("1.01")
 
 		// Act
-		res := calc.Calculate(&p)
+		resStandard, errStandard := calcStandard.Calculate(&p)
+		resBooks, errBooks := calcBooks.Calculate(&p)
 
 		// Assert
-		assert.Equal(t, expectedTotalDiscount, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedFinalPrice, res.TotalPrice().Value)
+		assert.NoError(t, errStandard)
+		assert.NoError(t, errBooks)
+		assert.Equal(t, expectedStandardDiscount, resStandard.TotalDiscount().Amount)
+		assert.Equal(t, expectedBooksDiscount, resBooks.TotalDiscount().Amount)
+		assert.Equal(t, expectedBooksTax, resBooks.TaxAmount().Amount)
 	})
 
-	// Tests EXPENSE requirement where costs can be applied onto a price
-	t.Run("TEST_EXPENSE_REQUIREMENT", func(t *testing.T) {
+	// Tests fixed discounts combined with percentage discounts after tax
+	t.Run("TEST_FIXED_DISCOUNT", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(
+			*models.NewFixedDiscountRule("2.00 off", models.NewMoney(currency.USD, "2.00")),
+			*models.NewDiscountRule("Autumn sale", percentage("10")),
+		)
+
+		calcAdditive := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+		calcMultiplicative := NewCalculator(tax, discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
-		costPercentage := models.NewExpensePercentage("Packaging", 1)
+		// additive: 2.00 + 20.25 * 10% = 4.025, multiplicative: 2.00 + 18.25 * 10% = 3.825
+		expectedDiscountsAdditive := units("4.03")
+		expectedTotalAdditive := units("20.28")
+		expectedDiscountsMultiplicative := units("3.83")
+		expectedTotalMultiplicative := units("20.48")
 
-		costs := models.NewCosts(costAbsolute, costPercentage)
+		// Act
+		resAdditive, errAdditive := calcAdditive.Calculate(&p)
+		resMultiplicative, errMultiplicative := calcMultiplicative.Calculate(&p)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		// Assert
+		assert.NoError(t, errAdditive)
+		assert.NoError(t, errMultiplicative)
+		assert.Equal(t, units("4.05"), resAdditive.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscountsAdditive, resAdditive.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalAdditive, resAdditive.TotalPrice().Amount)
+		assert.Equal(t, expectedDiscountsMultiplicative, resMultiplicative.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotalMultiplicative, resMultiplicative.TotalPrice().Amount)
+	})
 
-		tax := *models.NewTax(21)
-		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
-			models.NoPrecedence,
+	// Tests that fixed discounts before tax reduce the tax base and never exceed the price
+	t.Run("TEST_FIXED_DISCOUNT_BEFORE_TAX", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		partial := *models.NewDiscounts(*models.NewFixedDiscountRule("2.00 off", models.NewMoney(currency.USD, "2.00")).WithBeforeTax(true))
+		whole := *models.NewDiscounts(
+			*models.NewFixedDiscountRule("25.00 off", models.NewMoney(currency.USD, "25.00")).WithBeforeTax(true),
+			*models.NewFixedDiscountRule("5.00 off", models.NewMoney(currency.USD, "5.00")),
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calcPartial := NewCalculator(tax, partial, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+		calcWhole := NewCalculator(tax, whole, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		expectedTax := 4.25
-		expectedDiscount := 4.46
-		expectedTotal := 22.45
+		expectedTaxPartial := units("3.65")
+		expectedTotalPartial := units("21.90")
+		expectedDiscountsWhole := units("20.25")
 
 		// Act
-		res := calc.Calculate(&p)
+		resPartial, errPartial := calcPartial.Calculate(&p)
+		resWhole, errWhole := calcWhole.Calculate(&p)
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscount, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.NoError(t, errPartial)
+		assert.NoError(t, errWhole)
+		assert.Equal(t, expectedTaxPartial, resPartial.TaxAmount().Amount)
+		assert.Equal(t, expectedTotalPartial, resPartial.TotalPrice().Amount)
+		assert.True(t, resWhole.TaxAmount().IsZero())
+		assert.Equal(t, expectedDiscountsWhole, resWhole.TotalDiscount().Amount)
+		assert.True(t, resWhole.TotalPrice().IsZero())
 	})
 
-	// Tests COMBINING requirement where there are two different methods of combining discounts
-	t.Run("TEST_COMBINING_REQUIREMENT_ADDITIVE", func(t *testing.T) {
+	// Tests that fixed discounts are credited on credit notes
+	t.Run("TEST_FIXED_DISCOUNT_CREDIT", func(t *testing.T) {
+		p := models.NewCreditProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(*models.NewFixedDiscountRule("2.00 off", models.NewMoney(currency.USD, "2.00")))
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
-		costPercentage := models.NewExpensePercentage("Packaging", 1)
+		expectedDiscounts := units("-2.00")
+		expectedTotal := units("-22.30")
 
-		costs := models.NewCosts(costAbsolute, costPercentage)
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+	})
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+	// Tests that fixed discounts in another currency are rejected
+	t.Run("TEST_FIXED_DISCOUNT_CURRENCY_MISMATCH", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(*models.NewFixedDiscountRule("5 EUR off", models.NewMoney(currency.EUR, "5")))
 
-		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
-			models.NoPrecedence,
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+		assert.Nil(t, res)
+	})
+
+	// Tests that the cap limits the sum of all discounts in the collection
+	t.Run("TEST_DISCOUNT_RULES_CAP", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscounts(
+			*models.NewDiscountRule("Autumn sale", percentage("10")),
+			*models.NewDiscountRule("Book week", percentage("5")).WithUPCs(123456).WithBeforeTax(true),
+			*models.NewDiscountRule("Loyalty", percentage("3")),
+			*models.NewDiscountRule("Newsletter", percentage("2")).WithBeforeTax(true),
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(1, "3", currency.USD))
 
 		// Arrange
-		expectedTax := 4.25
-		expectedDiscountsAdditive := 4.46
-		expectedTotalAdditive := 22.45
+		expectedTax := units("3.77")
+		expectedDiscounts := units("3.00")
+		expectedTotal := units("21.02")
 
 		// Act
-		resAdditive := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
 
 		// Assert
-		assert.Equal(t, expectedTax, resAdditive.TaxAmount().Value)
-		assert.Equal(t, expectedDiscountsAdditive, resAdditive.TotalDiscount().Value)
-		assert.Equal(t, expectedTotalAdditive, resAdditive.TotalPrice().Value)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 	})
 
-	t.Run("TEST_COMBINING_REQUIREMENT_MULTIPLICATIVE", func(t *testing.T) {
+	// Tests that taxable expenses are part of the tax base and the tax on them is reported
+	t.Run("TEST_TAXABLE_EXPENSES", func(t *testing.T) {
+		transport := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.20")).WithTaxable(true)
+		packaging := models.NewExpensePercentage("Packaging", percentage("1"))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts(transport, packaging))
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
+
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
-		costPercentage := models.NewExpensePercentage("Packaging", 1)
+		// (20.25 + 2.20) * 20% = 4.49, of which 2.20 * 20% = 0.44 is tax on the transport
+		expectedTax := units("4.49")
+		expectedExpenseTax := units("0.44")
+		expectedTotal := units("27.14")
 
-		costs := models.NewCosts(costAbsolute, costPercentage)
+		// Act
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		// Assert
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedExpenseTax, res.ExpenseTax().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Contains(t, report, "Tax on expenses = 0.44 USD")
+	})
 
-		tax := *models.NewTax(21)
+	// Tests that discountable expenses are discounted with the price, and discounts that take precedence reduce their taxable amount
+	t.Run("TEST_TAXABLE_DISCOUNTABLE_EXPENSES_PRECEDENCE", func(t *testing.T) {
+		transport := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.20")).WithTaxable(true).WithDiscountable(true)
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts(transport))
 
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
-			models.NoPrecedence,
-		)
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.PrecedenceUniversal)
 
-		calc := NewCalculator(tax, discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		expectedTax := 4.25
-		expectedDiscountsMultiplicative := 4.24
-		expectedTotalMultiplicative := 22.66
+		// (20.25 + 2.20) * 15% = 3.3675 discount, (22.45 - 3.3675) * 20% = 3.8165 tax, 1.87 * 20% = 0.374 on the transport
+		expectedDiscount := units("3.37")
+		expectedTax := units("3.82")
+		expectedExpenseTax := units("0.37")
+		expectedTotal := units("22.90")
 
 		// Act
-		resMultiplicative := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
 
 		// Assert
-		assert.Equal(t, expectedTax, resMultiplicative.TaxAmount().Value)
-		assert.Equal(t, expectedDiscountsMultiplicative, resMultiplicative.TotalDiscount().Value)
-		assert.Equal(t, expectedTotalMultiplicative, resMultiplicative.TotalPrice().Value)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedExpenseTax, res.ExpenseTax().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 	})
 
-	// Tests CURRENCY requirement where different currencies can be used. Checking default case for USD
-	t.Run("TEST_CURRENCY_USD", func(t *testing.T) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+	// Tests that discountable expenses that are not taxable are discounted but not taxed
+	t.Run("TEST_DISCOUNTABLE_EXPENSES", func(t *testing.T) {
+		transport := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.20")).WithDiscountable(true)
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts(transport))
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(0, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
 			models.NoPrecedence)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		expectedTax := 4.05
-		expectedTotal := 24.30
-		expectedCurrencyTax := currency.USD
-		expectedCurrencyTotal := currency.USD
-		expectedCurrencyStarting := currency.USD
+		expectedDiscount := units("3.37")
+		expectedTax := units("4.05")
+		expectedTotal := units("23.13")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedCurrencyTotal, res.TotalPrice().Currency)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
-		assert.Equal(t, expectedCurrencyStarting, res.StartingPrice().Currency)
-		assert.Equal(t, expectedCurrencyTax, res.TaxAmount().Currency)
+		assert.Equal(t, expectedDiscount, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.True(t, res.ExpenseTax().IsZero())
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.NotContains(t, report, "Tax on expenses")
+	})
+
+	// Tests that orders are taxed with the scheduled rate in force at the time of the transaction
+	t.Run("TEST_TAX_SCHEDULE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		change := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		schedule := models.NewTaxSchedule(models.CategoryStandard,
+			models.ScheduledRate{Rate: percentage("20"), ValidTo: change},
+			models.ScheduledRate{Rate: percentage("21"), ValidFrom: change},
+		)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithTaxSchedule(schedule)
 
+		// Arrange
+		expectedHistoricalTax := units("4.05")
+		expectedCurrentTax := units("4.25")
+
+		// Act
+		historical, errHistorical := calc.WithTransactionTime(change.AddDate(0, 0, -1)).Calculate(&p)
+		current, errCurrent := calc.WithTransactionTime(change).Calculate(&p)
+
+		// Assert
+		assert.NoError(t, errHistorical)
+		assert.NoError(t, errCurrent)
+		assert.Equal(t, expectedHistoricalTax, historical.TaxAmount().Amount)
+		assert.Equal(t, expectedCurrentTax, current.TaxAmount().Amount)
 	})
 
-	// Tests CURRENCY requirement where different currencies can be used. Checking default case for GBP
-	t.Run("TEST_CURRENCY_GBP", func(t *testing.T) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(1, 17.76), models.NewCosts())
+	// Tests that orders dated before the first scheduled rate are rejected
+	t.Run("TEST_TAX_SCHEDULE_NOT_IN_FORCE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		change := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		schedule := models.NewTaxSchedule(models.CategoryStandard, models.ScheduledRate{Rate: percentage("21"), ValidFrom: change})
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithTaxSchedule(schedule).
+			WithTransactionTime(change.AddDate(-1, 0, 0))
+
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrRateNotInForce)
+		assert.Nil(t, res)
+	})
+
+	// Tests that exempt products are not taxed and the reason is recorded on the result
+	t.Run("TEST_EXEMPT_PRODUCT", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).
+			WithTaxExemption("Prescription medicine")
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(0, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
 			models.NoPrecedence)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
-		expectedTax := 3.55
-		expectedTotal := 21.31
-		expectedCurrencyTax := currency.GBP
-		expectedCurrencyTotal := currency.GBP
-		expectedCurrencyStarting := currency.GBP
+		expectedTax := units("0")
+		expectedTotal := units("20.25")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
+		report := res.Report()
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedCurrencyTotal, res.TotalPrice().Currency)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
-		assert.Equal(t, expectedCurrencyStarting, res.StartingPrice().Currency)
-		assert.Equal(t, expectedCurrencyTax, res.TaxAmount().Currency)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Equal(t, "Prescription medicine", res.Exemption())
+		assert.Contains(t, report, "Tax exemption: Prescription medicine")
+	})
+
+	// Tests that a certificate exempting part of the tax reduces the tax rate for the categories in its scope
+	t.Run("TEST_EXEMPTION_CERTIFICATE_PARTIAL", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts()).
+			WithTaxCategory(models.CategoryBooks)
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		certificate := exemption.NewPartialCertificate("EDU-42", "Educational institution", percentage("50"), time.Time{}, models.CategoryBooks)
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithExemptions(certificate)
+
+		// Arrange
+		// 20.25 * 10% = 2.025
+		expectedTax := units("2.03")
+		expectedTotal := units("22.28")
 
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
+		assert.Equal(t, "Educational institution (certificate EDU-42)", res.Exemption())
+	})
+
+	// Tests that certificates only exempt transactions made before they expire and in their scope
+	t.Run("TEST_EXEMPTION_CERTIFICATE_NOT_APPLICABLE", func(t *testing.T) {
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(currency.USD, "20.25"), models.NewCosts())
+
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence)
+
+		expires := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		expired := exemption.NewCertificate("NP-1", "Non-profit organization", expires)
+		outOfScope := exemption.NewCertificate("RS-1", "Resale", time.Time{}, models.CategoryFood)
+
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD)).
+			WithExemptions(expired, outOfScope).
+			WithTransactionTime(expires)
+
+		// Arrange
+		expectedTax := units("4.05")
+
+		// Act
+		res, err := calc.Calculate(&p)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Empty(t, res.Exemption())
 	})
 
 	// Tests PRECISION requirement where calculations need to be performed with 4 decimal places precision but reported with 2 decimal places
 	t.Run("TEST_PRECISION", func(t *testing.T) {
 		// Arrange
-		costPercentage := models.NewExpensePercentage("Packaging", 3)
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("3"))
 
 		costs := models.NewCosts(costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
-		discount := models.NewDiscount(*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+		tax := *models.NewTax(percentage("21"))
+		discount := models.NewDiscount(*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence)
 
-		calc := NewCalculator(tax, *discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, *discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
 
 		// Calculated high-precision numbers (4 decimals)
-		expectedTaxAmountPrecise := 4.2525
-		expectedUniversalDiscountPrecise := 3.0375
-		expectedSpecialDiscountPrecise := 1.2049
-		expectedTotalDiscountPrecise := 4.2424
-		expectedCostsPrecise := 0.6075
+		expectedTaxAmountPrecise := units("4.2525")
+		expectedUniversalDiscountPrecise := units("3.0375")
+		expectedSpecialDiscountPrecise := units("1.2049")
+		expectedTotalDiscountPrecise := units("4.2424")
+		expectedCostsPrecise := units("0.6075")
 
 		// Resulting values (2 decimals)
-		expectedStartingPrice := 20.25
-		expectedTax := 4.25
-		expectedDiscounts := 4.24
-		expectedTotal := 20.87
+		expectedStartingPrice := units("20.25")
+		expectedTax := units("4.25")
+		expectedDiscounts := units("4.24")
+		expectedTotal := units("20.87")
 
 		// Act
 		res, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise := calc.calculatePrecision(&p)
@@ -473,43 +1769,43 @@ func TestCalculate(t *testing.T) {
 		assert.Equal(t, expectedCostsPrecise, costsPrecise)
 
 		// Resulting values (2 decimals)
-		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 
 	})
 
 	t.Run("TEST_PRECISION_ADDITIVE_COMBINING", func(t *testing.T) {
 
-		costPercentage := models.NewExpensePercentage("Packaging", 3)
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("3"))
 
 		costs := models.NewCosts(costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discounts := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence)
 
-		calc := NewCalculator(tax, discounts, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discounts, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
 
 		// Calculated high-precision numbers (4 decimals)
-		expectedTaxAmountPrecise := 4.2525
-		expectedUniversalDiscountPrecise := 3.0375
-		expectedSpecialDiscountPrecise := 1.4175
-		expectedTotalDiscountPrecise := 4.455
-		expectedCostsPrecise := 0.6075
+		expectedTaxAmountPrecise := units("4.2525")
+		expectedUniversalDiscountPrecise := units("3.0375")
+		expectedSpecialDiscountPrecise := units("1.4175")
+		expectedTotalDiscountPrecise := units("4.455")
+		expectedCostsPrecise := units("0.6075")
 
 		// Resulting values (2 decimals)
-		expectedStartingPrice := 20.25
-		expectedTax := 4.25
-		expectedDiscounts := 4.46
-		expectedTotal := 20.66
+		expectedStartingPrice := units("20.25")
+		expectedTax := units("4.25")
+		expectedDiscounts := units("4.46")
+		expectedTotal := units("20.66")
 
 		// Act
 		res, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise := calc.calculatePrecision(&p)
@@ -524,43 +1820,43 @@ func TestCalculate(t *testing.T) {
 		assert.Equal(t, expectedCostsPrecise, costsPrecise)
 
 		// Resulting values (2 decimals)
-		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 
 	})
 
 	t.Run("TEST_PRECISION_UNIVERSAL_DISCOUNT_PRECEDENCE", func(t *testing.T) {
 
-		costPercentage := models.NewExpensePercentage("Packaging", 3)
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("3"))
 
 		costs := models.NewCosts(costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.PrecedenceUniversal)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
 
 		// Calculated high-precision numbers (4 decimals)
-		expectedTaxAmountPrecise := 3.6146
-		expectedUniversalDiscountPrecise := 3.0375
-		expectedSpecialDiscountPrecise := 1.2049
-		expectedTotalDiscountPrecise := 4.2424
-		expectedCostsPrecise := 0.6075
+		expectedTaxAmountPrecise := units("3.6146")
+		expectedUniversalDiscountPrecise := units("3.0375")
+		expectedSpecialDiscountPrecise := units("1.2049")
+		expectedTotalDiscountPrecise := units("4.2424")
+		expectedCostsPrecise := units("0.6075")
 
 		// Resulting values (2 decimals)
-		expectedStartingPrice := 20.25
-		expectedTax := 3.61
-		expectedDiscounts := 4.24
-		expectedTotal := 20.23
+		expectedStartingPrice := units("20.25")
+		expectedTax := units("3.61")
+		expectedDiscounts := units("4.24")
+		expectedTotal := units("20.23")
 
 		// Act
 		res, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise := calc.calculatePrecision(&p)
@@ -575,44 +1871,44 @@ func TestCalculate(t *testing.T) {
 		assert.Equal(t, expectedCostsPrecise, costsPrecise)
 
 		// Resulting values (2 decimals)
-		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 
 	})
 
 	t.Run("TEST_PRECISION_SPECIAL_DISCOUNT_PRECEDENCE", func(t *testing.T) {
 
-		costPercentage := models.NewExpensePercentage("Packaging", 3)
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("3"))
 
 		costs := models.NewCosts(costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.PrecedenceSpecial,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
 
 		// Calculated high-precision numbers (4 decimals)
-		expectedTaxAmountPrecise := 3.9548
-		expectedUniversalDiscountPrecise := 2.8249
-		expectedSpecialDiscountPrecise := 1.4175
-		expectedTotalDiscountPrecise := 4.2424
-		expectedCostsPrecise := 0.6075
+		expectedTaxAmountPrecise := units("3.9548")
+		expectedUniversalDiscountPrecise := units("2.8249")
+		expectedSpecialDiscountPrecise := units("1.4175")
+		expectedTotalDiscountPrecise := units("4.2424")
+		expectedCostsPrecise := units("0.6075")
 
 		// Resulting values (2 decimals)
-		expectedStartingPrice := 20.25
-		expectedTax := 3.95
-		expectedDiscounts := 4.24
-		expectedTotal := 20.57
+		expectedStartingPrice := units("20.25")
+		expectedTax := units("3.95")
+		expectedDiscounts := units("4.24")
+		expectedTotal := units("20.57")
 
 		// Act
 		res, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise := calc.calculatePrecision(&p)
@@ -627,10 +1923,10 @@ func TestCalculate(t *testing.T) {
 		assert.Equal(t, expectedCostsPrecise, costsPrecise)
 
 		// Resulting values (2 decimals)
-		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Value)
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedStartingPrice, res.StartingPrice().Amount)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 
 	})
 }
@@ -639,42 +1935,54 @@ func TestCalculateCosts(t *testing.T) {
 
 	t.Run("TEST_CALCULATE_COSTS_NEGATIVE_PRICE", func(t *testing.T) {
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
-		costPercentage := models.NewExpensePercentage("Packaging", 1)
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
 		allExpenses := models.NewCosts(costAbsolute, costPercentage)
 
 		// Act
-		res := calculateCosts(allExpenses, models.NewMoney(currency.USD, -25))
-		expectedResult := 2.2
+		res, _ := calculateCosts(allExpenses, models.NewMoney(currency.USD, "-25"))
+		expectedResult := units("2.2")
 
 		// Assert
-		assert.Equal(t, res, expectedResult)
+		assert.Equal(t, res.Amount, expectedResult)
 	})
 
 	t.Run("TEST_CALCULATE_COSTS_ONLY_ABSOLUTE", func(t *testing.T) {
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
 		allExpenses := models.NewCosts(costAbsolute)
 
 		// Act
-		res := calculateCosts(allExpenses, models.NewMoney(currency.USD, 5))
-		expectedResult := 2.2
+		res, _ := calculateCosts(allExpenses, models.NewMoney(currency.USD, "5"))
+		expectedResult := units("2.2")
 
 		// Assert
-		assert.Equal(t, res, expectedResult)
+		assert.Equal(t, res.Amount, expectedResult)
 	})
 
 	t.Run("TEST_CALCULATE_COSTS_ONLY_PERCENTAGE", func(t *testing.T) {
 		// Arrange
-		costPercentage := models.NewExpensePercentage("Packaging", 10)
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("10"))
 		allExpenses := models.NewCosts(costPercentage)
 
 		// Act
-		res := calculateCosts(allExpenses, models.NewMoney(currency.USD, 50))
-		var expectedResult float64 = 5
+		res, _ := calculateCosts(allExpenses, models.NewMoney(currency.USD, "50"))
+		expectedResult := units("5")
 
 		// Assert
-		assert.Equal(t, res, expectedResult)
+		assert.Equal(t, res.Amount, expectedResult)
+	})
+
+	t.Run("TEST_CALCULATE_COSTS_CURRENCY_MISMATCH", func(t *testing.T) {
+		// Arrange
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.GBP, "2.2"))
+		allExpenses := models.NewCosts(costAbsolute)
+
+		// Act
+		_, err := calculateCosts(allExpenses, models.NewMoney(currency.USD, "5"))
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
 	})
 }
 
@@ -682,146 +1990,100 @@ func TestDiscountCap(t *testing.T) {
 
 	// Tests CAP requirement where discounts can have a specific cap. Testing case where it's a percentage-based cap
 	t.Run("TEST_CAP_REQUIREMENT_PERCENTAGE", func(t *testing.T) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(2, 20))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(2, "20", currency.USD))
 
 		// Arrange
-		expectedTax := 4.25
-		expectedDiscounts := 4.05
-		expectedTotal := 20.45
+		expectedTax := units("4.25")
+		expectedDiscounts := units("4.05")
+		expectedTotal := units("20.45")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 	})
 
 	// Tests CAP requirement where discounts can have a specific cap. Testing case where it's an absolute amount
 	t.Run("TEST_CAP_REQUIREMENT_ABSOLUTE", func(t *testing.T) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(1, 4))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(1, "4", currency.USD))
 
 		// Arrange
-		expectedTax := 4.25
-		expectedDiscounts := 4.00
-		expectedTotal := 20.50
+		expectedTax := units("4.25")
+		expectedDiscounts := units("4.00")
+		expectedTotal := units("20.50")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 	})
 
 	// Tests CAP requirement where discounts can have a specific cap. Testing case where it's a percentage-based cap with the second set of parameters from the example
 	t.Run("TEST_CAP_REQUIREMENT_PERCENTAGE_SECOND", func(t *testing.T) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(2, 30))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(2, "30", currency.USD))
 
 		// Arrange
-		expectedTax := 4.25
-		expectedDiscounts := 4.46
-		expectedTotal := 20.05
+		expectedTax := units("4.25")
+		expectedDiscounts := units("4.46")
+		expectedTotal := units("20.05")
 
 		// Act
-		res := calc.Calculate(&p)
+		res, err := calc.Calculate(&p)
+		assert.NoError(t, err)
 
 		// Assert
-		assert.Equal(t, expectedTax, res.TaxAmount().Value)
-		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Value)
-		assert.Equal(t, expectedTotal, res.TotalPrice().Value)
+		assert.Equal(t, expectedTax, res.TaxAmount().Amount)
+		assert.Equal(t, expectedDiscounts, res.TotalDiscount().Amount)
+		assert.Equal(t, expectedTotal, res.TotalPrice().Amount)
 	})
 }
 
 // calculatePrecision functions the same as the regular Calculate() method but returns amounts with 4 decimal precision for testing purposes
-func (c *calculator) calculatePrecision(p *models.Product) (res *result.Result, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise float64) {
-	startingPrice := p.Price()
-	productPrice := p.Price()
-
-	switch c.discount.TakesPrecedence {
-	case 1:
-		c.discount.UniversalDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.UniversalDiscount.Rate(), productPrice.Value)
-		c.tax.Amount.Value = utils.AmountFromPercentage(c.tax.Rate(), productPrice.Value-c.discount.UniversalDiscount.Amount.Value)
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), productPrice.Value-c.discount.UniversalDiscount.Amount.Value)
-		}
-	case 2:
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), productPrice.Value)
-		}
-		c.tax.Amount.Value = utils.AmountFromPercentage(c.tax.Rate(), productPrice.Value-c.discount.SpecialDiscount.Amount.Value)
-		c.discount.UniversalDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.UniversalDiscount.Rate(), productPrice.Value-c.discount.SpecialDiscount.Amount.Value)
-
-	default:
-		c.tax.Amount.Value = utils.AmountFromPercentage(c.tax.Rate(), productPrice.Value)
-		c.discount.UniversalDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.UniversalDiscount.Rate(), productPrice.Value)
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), productPrice.Value)
-		}
-	}
-
-	var sumDiscount float64
-
-	if c.combineType == combining.TypeAdditive {
-		sumDiscount = c.cap.CalculateCap(startingPrice, (c.discount.SpecialDiscount.Amount.Value + c.discount.UniversalDiscount.Amount.Value))
-		productPrice.Value = (startingPrice.Value + c.tax.Amount.Value) - sumDiscount
-	} else {
-		if c.discount.SpecialDiscount.UPC() == p.UPC() {
-			c.discount.SpecialDiscount.Amount.Value = utils.AmountFromPercentage(c.discount.SpecialDiscount.Rate(), startingPrice.Value-c.discount.UniversalDiscount.Amount.Value)
-		}
-		sumDiscount = c.cap.CalculateCap(startingPrice, c.discount.UniversalDiscount.Amount.Value+c.discount.SpecialDiscount.Amount.Value)
-		productPrice.Value = (startingPrice.Value + c.tax.Amount.Value) - sumDiscount
-	}
+func (c *calculator) calculatePrecision(p *models.Product) (res *result.Result, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise int64) {
+	res, _ = c.Calculate(p)
 
-	costs := calculateCosts(p.Cost(), startingPrice)
-
-	productPrice.Value += costs
-
-	taxAmountPrecise = c.tax.Amount.Value
-	universalDiscountPrecise = c.discount.UniversalDiscount.Amount.Value
-	specialDiscountPrecise = c.discount.SpecialDiscount.Amount.Value
-	totalDiscountPrecise = sumDiscount
-	costsPrecise = costs
-
-	resCurrency := p.Price().Currency
-	res = result.NewResult(
-
-		models.NewMoney(resCurrency, format.ToDecimal(startingPrice.Value, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(c.tax.Amount.Value, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(sumDiscount, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(costs, 2)),
-		models.NewMoney(resCurrency, format.ToDecimal(productPrice.Value, 2)),
-		p.Cost(),
-	)
+	taxAmountPrecise = c.tax.Amount.Amount
+	universalDiscountPrecise = c.discountAmount("Universal discount")
+	specialDiscountPrecise = c.discountAmount("Special discount")
+	totalDiscount, _ := c.cap.CalculateCap(p.Price(), models.Money{Currency: p.Price().Currency, Amount: universalDiscountPrecise + specialDiscountPrecise})
+	totalDiscountPrecise = totalDiscount.Amount
+	costs, _ := calculateCosts(p.Cost(), p.Price())
+	costsPrecise = costs.Amount
 
 	res.Report()
 
@@ -829,19 +2091,41 @@ func (c *calculator) calculatePrecision(p *models.Product) (res *result.Result,
 	return res, taxAmountPrecise, universalDiscountPrecise, specialDiscountPrecise, totalDiscountPrecise, costsPrecise
 }
 
+// discountAmount returns the precise amount of the discount rule with the given name, for testing the PRECISION requirement
+func (c *calculator) discountAmount(name string) int64 {
+	for _, rule := range c.discount.Rules {
+		if rule.Name() == name {
+			return rule.Amount.Amount
+		}
+	}
+	return 0
+}
+
+// units returns the amount of a money value with 4 decimal precision, for comparing expected values
+func units(value string) int64 {
+	m, _ := models.ParseMoney(currency.USD, value)
+	return m.Amount
+}
+
+// percentage parses a percentage for test cases
+func percentage(value string) utils.Percentage {
+	p, _ := utils.ParsePercentage(value)
+	return p
+}
+
 func BenchmarkCalculate(b *testing.B) {
 
 	b.Run("BENCHMARK_TAX_REQUIREMENT", func(b *testing.B) {
-		tax := *models.NewTax(20)
-		discount := models.Discount{
-			UniversalDiscount: *models.NewUniversalDiscount(0, models.Money{}),
-			SpecialDiscount:   *models.NewSpecialDiscount(0, 0, models.Money{}),
-			TakesPrecedence:   models.NoPrecedence,
-		}
+		tax := *models.NewTax(percentage("20"))
+		discount := *models.NewDiscount(
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
+			models.NoPrecedence,
+		)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.Calculate(&p)
@@ -849,31 +2133,31 @@ func BenchmarkCalculate(b *testing.B) {
 	})
 
 	b.Run("BENCHMARK_DISCOUNT_REQUIREMENT", func(b *testing.B) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		calc.Calculate(&p)
 	})
 
 	b.Run("BENCHMARK_REPORT_REQUIREMENT", func(b *testing.B) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax1 := *models.NewTax(20)
+		tax1 := *models.NewTax(percentage("20"))
 		discount1 := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		case1 := NewCalculator(tax1, discount1, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		case1 := NewCalculator(tax1, discount1, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Arrange
 		case1.Calculate(&p)
@@ -881,16 +2165,16 @@ func BenchmarkCalculate(b *testing.B) {
 	})
 
 	b.Run("BENCHMARK_SELECTIVE_REQUIREMENT", func(b *testing.B) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.Calculate(&p)
@@ -898,16 +2182,16 @@ func BenchmarkCalculate(b *testing.B) {
 	})
 
 	b.Run("BENCHMARK_PRECEDENCE_REQUIREMENT", func(b *testing.B) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.PrecedenceSpecial,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.Calculate(&p)
@@ -916,21 +2200,21 @@ func BenchmarkCalculate(b *testing.B) {
 
 	b.Run("BENCHMARK_EXPENSE_REQUIREMENT", func(b *testing.B) {
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
-		costPercentage := models.NewExpensePercentage("Packaging", 1)
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
 
 		costs := models.NewCosts(costAbsolute, costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.Calculate(&p)
@@ -939,22 +2223,22 @@ func BenchmarkCalculate(b *testing.B) {
 
 	b.Run("BENCHMARK_COMBINING_REQUIREMENT", func(b *testing.B) {
 		// Arrange
-		costAbsolute := models.NewExpenseAbsolute("Transport", 2.2)
-		costPercentage := models.NewExpensePercentage("Packaging", 1)
+		costAbsolute := models.NewExpenseAbsolute("Transport", models.NewMoney(currency.USD, "2.2"))
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("1"))
 
 		costs := models.NewCosts(costAbsolute, costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
+		tax := *models.NewTax(percentage("21"))
 
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+			*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence,
 		)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.Calculate(&p)
@@ -962,15 +2246,15 @@ func BenchmarkCalculate(b *testing.B) {
 	})
 
 	b.Run("BENCHMARK_CURRENCY_USD", func(b *testing.B) {
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts())
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts())
 
-		tax := *models.NewTax(20)
+		tax := *models.NewTax(percentage("20"))
 		discount := *models.NewDiscount(
-			*models.NewUniversalDiscount(0, models.Money{}),
-			*models.NewSpecialDiscount(0, 0, models.Money{}),
+			*models.NewUniversalDiscount(percentage("0"), models.Money{}),
+			*models.NewSpecialDiscount(0, percentage("0"), models.Money{}),
 			models.NoPrecedence)
 
-		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, discount, combining.TypeAdditive, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.Calculate(&p)
@@ -979,18 +2263,18 @@ func BenchmarkCalculate(b *testing.B) {
 	b.Run("BENCHMARK_PRECISION_REQUIREMENT", func(b *testing.B) {
 
 		// Arrange
-		costPercentage := models.NewExpensePercentage("Packaging", 3)
+		costPercentage := models.NewExpensePercentage("Packaging", percentage("3"))
 
 		costs := models.NewCosts(costPercentage)
 
-		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, 20.25), models.NewCosts(costs))
+		p := models.NewProduct("The Little Prince", 123456, models.NewMoney(0, "20.25"), models.NewCosts(costs))
 
-		tax := *models.NewTax(21)
-		discount := models.NewDiscount(*models.NewUniversalDiscount(15, models.Money{}),
-			*models.NewSpecialDiscount(123456, 7, models.Money{}),
+		tax := *models.NewTax(percentage("21"))
+		discount := models.NewDiscount(*models.NewUniversalDiscount(percentage("15"), models.Money{}),
+			*models.NewSpecialDiscount(123456, percentage("7"), models.Money{}),
 			models.NoPrecedence)
 
-		calc := NewCalculator(tax, *discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, 100))
+		calc := NewCalculator(tax, *discount, combining.TypeMultiplicative, cap.NewDiscountCapTesting(0, "100", currency.USD))
 
 		// Act
 		calc.calculatePrecision(&p)
@@ -3,78 +3,244 @@ package result
 import (
 	"fmt"
 
+	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
 )
 
 // Result stores calculator results
 type Result struct {
 	startingPrice models.Money
-	taxAmount     models.Money
+	taxes         []models.TaxLine
 	totalDiscount models.Money
 	totalExpenses models.Money
 	totalPrice    models.Money
-	costs         models.Costs
+	expenses      []models.ExpenseLine
+	credit        bool
+	display       *Result
+	rate          int64
+	cashRounding  models.Money
+	grossPrice    *models.Money
+	notes         []string
+	exemption     string
+	expenseTax    models.Money
 }
 
 // NewResult constructor
-func NewResult(startingPrice, taxAmount, totalDiscount, totalExpenses, totalPrice models.Money, costs models.Costs) *Result {
+func NewResult(startingPrice models.Money, taxes []models.TaxLine, totalDiscount, totalExpenses, totalPrice models.Money, expenses []models.ExpenseLine) *Result {
 	return &Result{
 		startingPrice: startingPrice,
-		taxAmount:     taxAmount,
+		taxes:         taxes,
 		totalDiscount: totalDiscount,
 		totalExpenses: totalExpenses,
 		totalPrice:    totalPrice,
-		costs:         costs,
+		expenses:      expenses,
 	}
 }
 
-// Report prints a report of all relevant results. Does not print amounts with null or zero values
+// Report prints a report of all relevant results. Does not print amounts with null or zero values.
+// If the result has a display currency every line is followed by its amount in the display currency
 func (r *Result) Report() string {
 
-	// Starting price will be reported
-	starting := fmt.Sprintf("Cost = %.2f %v\n", r.StartingPrice().Value, r.StartingPrice().Currency.String())
+	// credit notes are marked at the top of the report
+	var credit string
+	if r.IsCredit() {
+		credit = "CREDIT NOTE\n"
+		fmt.Print(credit)
+	}
+
+	// Starting price will be reported, gross prices are reported with their net price
+	var starting string
+	if gross, ok := r.GrossPrice(); ok {
+		var displayGross *models.Money
+		if r.display != nil {
+			if g, ok := r.display.GrossPrice(); ok {
+				displayGross = &g
+			}
+		}
+		starting = fmt.Sprintf("Gross = %v\nNet = %v\n", r.line(gross, displayGross), r.line(r.StartingPrice(), r.displayLine((*Result).StartingPrice)))
+	} else {
+		starting = fmt.Sprintf("Cost = %v\n", r.line(r.StartingPrice(), r.displayLine((*Result).StartingPrice)))
+	}
 	fmt.Print(starting)
 
-	// if the tax exists it will get reported
+	// if taxes exist they will be reported one by one
 	var tax string
-	if r.TaxAmount().Value != 0 {
-		tax = fmt.Sprintf("Tax = %.2f %v\n", r.TaxAmount().Value, r.TaxAmount().Currency.String())
-		fmt.Print(tax)
+	for i, t := range r.Taxes() {
+		if !t.Amount.IsZero() {
+			var display *models.Money
+			if r.display != nil && i < len(r.display.taxes) {
+				display = &r.display.taxes[i].Amount
+			}
+			line := fmt.Sprintf("%v = %v\n", t.Description, r.line(t.Amount, display))
+			fmt.Print(line)
+			tax += line
+		}
+	}
+
+	// the part of the taxes that is attributable to taxable expenses will be reported
+	if !r.ExpenseTax().IsZero() {
+		line := fmt.Sprintf("Tax on expenses = %v\n", r.line(r.ExpenseTax(), r.displayLine((*Result).ExpenseTax)))
+		fmt.Print(line)
+		tax += line
+	}
+
+	// the reason the product is exempt from tax will be reported
+	var exemption string
+	if r.Exemption() != "" {
+		exemption = fmt.Sprintf("Tax exemption: %v\n", r.Exemption())
+		fmt.Print(exemption)
 	}
 
 	// if discounts exist they will be reported
 	var totalDiscount string
-	if r.TotalDiscount().Value != 0 {
-		totalDiscount = fmt.Sprintf("Discounts = %.2f %v\n", r.TotalDiscount().Value, r.TotalDiscount().Currency.String())
+	if r.TotalDiscount().Amount != 0 {
+		totalDiscount = fmt.Sprintf("Discounts = %v\n", r.line(r.TotalDiscount(), r.displayLine((*Result).TotalDiscount)))
 		fmt.Print(totalDiscount)
 	}
 
 	// if expenses exist they will be reported one by one
-	if r.TotalExpenses().Value != 0 {
-		for _, c := range r.Costs().Expenses {
-			if c != nil {
-				c.ReportExpense(r.StartingPrice())
+	var expenses string
+	for i, e := range r.Expenses() {
+		if !e.Amount.IsZero() {
+			var display *models.Money
+			if r.display != nil && i < len(r.display.expenses) {
+				display = &r.display.expenses[i].Amount
 			}
+			line := fmt.Sprintf("%v = %v\n", e.Description, r.line(e.Amount, display))
+			fmt.Print(line)
+			expenses += line
 		}
 	}
 
+	// if the total was rounded for cash payment the adjustment will be reported
+	var cashRounding string
+	if !r.CashRounding().IsZero() {
+		cashRounding = fmt.Sprintf("Cash rounding = %v\n", r.line(r.CashRounding(), r.displayLine((*Result).CashRounding)))
+		fmt.Print(cashRounding)
+	}
+
 	// the total price will be reported
-	total := fmt.Sprintf("TOTAL = %.2f %v\n", r.TotalPrice().Value, r.TotalPrice().Currency.String())
+	total := fmt.Sprintf("TOTAL = %v\n", r.line(r.TotalPrice(), r.displayLine((*Result).TotalPrice)))
 	fmt.Print(total)
 
+	// the conversion rate is reported after the total
+	var rate string
+	if r.display != nil {
+		rate = fmt.Sprintf("Rate = 1 %v = %v %v\n", r.StartingPrice().Currency, currency.FormatRate(r.rate), r.display.StartingPrice().Currency)
+		fmt.Print(rate)
+	}
+
+	// notes are reported at the end of the report
+	var notes string
+	for _, n := range r.Notes() {
+		line := fmt.Sprintf("Note: %v\n", n)
+		fmt.Print(line)
+		notes += line
+	}
+
 	// concatenate all strings and return them (for test cases)
-	report := starting + tax + totalDiscount + total
+	report := credit + starting + tax + exemption + totalDiscount + expenses + cashRounding + total + rate + notes
 	return report
 }
 
+// line formats an amount followed by its amount in the display currency, if there is one
+func (r *Result) line(amount models.Money, display *models.Money) string {
+	if display == nil {
+		return amount.String()
+	}
+	return fmt.Sprintf("%v (%v)", amount, *display)
+}
+
+// displayLine returns the line of the display result, or nil if the result has no display currency
+func (r *Result) displayLine(get func(*Result) models.Money) *models.Money {
+	if r.display == nil {
+		return nil
+	}
+	m := get(r.display)
+	return &m
+}
+
+// WithDisplay sets the lines of the result converted into the display currency and the conversion rate used, with currency.RatePrecision decimals
+func (r *Result) WithDisplay(display *Result, rate int64) *Result {
+	r.display = display
+	r.rate = rate
+	return r
+}
+
+// WithGrossPrice sets the tax-inclusive price the starting price was back-calculated from
+func (r *Result) WithGrossPrice(gross models.Money) *Result {
+	r.grossPrice = &gross
+	return r
+}
+
+// RoundCash rounds the total price with the cash rounding policy and keeps the adjustment as its own line.
+// The increment of the policy is set for the store currency, so the total in the display currency is rounded
+// to the cash increment of the display currency with the same rounding mode
+func (r *Result) RoundCash(rounding models.CashRounding) *Result {
+	r.totalPrice, r.cashRounding = rounding.Apply(r.totalPrice)
+	if r.display != nil {
+		r.display.RoundCash(models.NewCurrencyCashRounding(rounding.Mode))
+	}
+	return r
+}
+
+// WithExpenseTax sets the part of the taxes that is attributable to taxable expenses
+func (r *Result) WithExpenseTax(tax models.Money) *Result {
+	r.expenseTax = tax
+	return r
+}
+
+// WithExemption sets the reason the product was exempt from all or part of its tax
+func (r *Result) WithExemption(reason string) *Result {
+	r.exemption = reason
+	return r
+}
+
+// AddNote adds a note that is printed at the end of the report, e.g. a reverse charge statement
+func (r *Result) AddNote(note string) *Result {
+	r.notes = append(r.notes, note)
+	return r
+}
+
+// MarkCredit marks the result as a refund or credit note
+func (r *Result) MarkCredit() *Result {
+	r.credit = true
+	if r.display != nil {
+		r.display.credit = true
+	}
+	return r
+}
+
 // StartingPrice returns a result's starting price
 func (r *Result) StartingPrice() models.Money {
 	return r.startingPrice
 }
 
-// TaxAmount returns a result's tax amount
+// GrossPrice returns the tax-inclusive price the starting price was back-calculated from, false if the price was net
+func (r *Result) GrossPrice() (models.Money, bool) {
+	if r.grossPrice == nil {
+		return models.Money{}, false
+	}
+	return *r.grossPrice, true
+}
+
+// TaxAmount returns a result's total tax amount, the sum of all of its taxes
 func (r *Result) TaxAmount() models.Money {
-	return r.taxAmount
+	sum := models.Money{Currency: r.startingPrice.Currency}
+	for _, t := range r.taxes {
+		sum.Amount += t.Amount.Amount
+	}
+	return sum
+}
+
+// ExpenseTax returns the part of a result's tax amount that is attributable to taxable expenses, it is included in the tax lines
+func (r *Result) ExpenseTax() models.Money {
+	return r.expenseTax
+}
+
+// Taxes returns a result's tax lines in the order they were calculated
+func (r *Result) Taxes() []models.TaxLine {
+	return r.taxes
 }
 
 // TaxDiscount returns a result's tax amount
@@ -92,7 +258,38 @@ func (r *Result) TotalPrice() models.Money {
 	return r.totalPrice
 }
 
-// Costs returns a result's costs
-func (r *Result) Costs() models.Costs {
-	return r.costs
+// CashRounding returns the adjustment made to the total price by cash rounding
+func (r *Result) CashRounding() models.Money {
+	return r.cashRounding
+}
+
+// Expenses returns a result's expense lines
+func (r *Result) Expenses() []models.ExpenseLine {
+	return r.expenses
+}
+
+// IsCredit returns true if the result is a refund or credit note
+func (r *Result) IsCredit() bool {
+	return r.credit
+}
+
+// Display returns the result in the display currency, or nil if it has none
+func (r *Result) Display() *Result {
+	return r.display
+}
+
+// Rate returns the conversion rate from the original currency to the display currency, with currency.RatePrecision decimals.
+// Returns zero if the result has no display currency
+func (r *Result) Rate() int64 {
+	return r.rate
+}
+
+// Notes returns a result's notes
+func (r *Result) Notes() []string {
+	return r.notes
+}
+
+// Exemption returns the reason the product was exempt from tax, empty if it was taxed in full
+func (r *Result) Exemption() string {
+	return r.exemption
 }
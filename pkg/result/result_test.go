@@ -5,6 +5,7 @@ import (
 
 	"github.com/radoslavboychev/price-calculator-kata/internal/currency"
 	"github.com/radoslavboychev/price-calculator-kata/internal/models"
+	"github.com/radoslavboychev/price-calculator-kata/internal/utils/format"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,15 +14,15 @@ func TestReport(t *testing.T) {
 	// Case when all entries are present
 	t.Run("TEST_REPORT_ALL_PRESENT", func(t *testing.T) {
 		// Arrange
-		startingPrice := models.NewMoney(currency.USD, 20.25)
-		taxAmount := models.NewMoney(currency.USD, 3)
-		totalDiscount := models.NewMoney(currency.USD, 2)
-		totalExpenses := models.NewMoney(currency.USD, 2)
-		totalPrice := models.NewMoney(currency.USD, 10)
-		costs := models.NewCosts()
+		startingPrice := models.NewMoney(currency.USD, "20.25")
+		taxes := []models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.USD, "3")}}
+		totalDiscount := models.NewMoney(currency.USD, "2")
+		totalExpenses := models.NewMoney(currency.USD, "2")
+		totalPrice := models.NewMoney(currency.USD, "10")
+		var costs []models.ExpenseLine
 
 		// Act
-		r := NewResult(startingPrice, taxAmount, totalDiscount, totalExpenses, totalPrice, costs)
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs)
 		str := r.Report()
 
 		// Assert
@@ -35,16 +36,16 @@ func TestReport(t *testing.T) {
 	t.Run("TEST_REPORT_NO_TAX", func(t *testing.T) {
 		// Arrange
 
-		// tax is nil
-		taxAmount := models.Money{}
-		startingPrice := models.NewMoney(currency.USD, 20.25)
-		totalDiscount := models.NewMoney(currency.USD, 2)
-		totalExpenses := models.NewMoney(currency.USD, 2)
-		totalPrice := models.NewMoney(currency.USD, 10)
-		costs := models.NewCosts()
+		// there are no taxes
+		var taxes []models.TaxLine
+		startingPrice := models.NewMoney(currency.USD, "20.25")
+		totalDiscount := models.NewMoney(currency.USD, "2")
+		totalExpenses := models.NewMoney(currency.USD, "2")
+		totalPrice := models.NewMoney(currency.USD, "10")
+		var costs []models.ExpenseLine
 
 		// Act
-		r := NewResult(startingPrice, taxAmount, totalDiscount, totalExpenses, totalPrice, costs)
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs)
 		str := r.Report()
 
 		// Assert
@@ -58,14 +59,14 @@ func TestReport(t *testing.T) {
 	t.Run("TEST_REPORT_NO_DISCOUNT", func(t *testing.T) {
 		// Arrange
 		totalDiscount := models.Money{}
-		startingPrice := models.NewMoney(currency.USD, 20.25)
-		taxAmount := models.NewMoney(currency.USD, 3)
-		totalExpenses := models.NewMoney(currency.USD, 2)
-		totalPrice := models.NewMoney(currency.USD, 10)
-		costs := models.NewCosts()
+		startingPrice := models.NewMoney(currency.USD, "20.25")
+		taxes := []models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.USD, "3")}}
+		totalExpenses := models.NewMoney(currency.USD, "2")
+		totalPrice := models.NewMoney(currency.USD, "10")
+		var costs []models.ExpenseLine
 
 		// Act
-		r := NewResult(startingPrice, taxAmount, totalDiscount, totalExpenses, totalPrice, costs)
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs)
 		str := r.Report()
 
 		// Assert
@@ -78,14 +79,14 @@ func TestReport(t *testing.T) {
 	t.Run("TEST_REPORT_ALL_IS_NIL", func(t *testing.T) {
 		// Arrange
 		startingPrice := models.Money{}
-		taxAmount := models.Money{}
+		var taxes []models.TaxLine
 		totalDiscount := models.Money{}
 		totalExpenses := models.Money{}
 		totalPrice := models.Money{}
-		costs := models.NewCosts()
+		var costs []models.ExpenseLine
 
 		// Act
-		r := NewResult(startingPrice, taxAmount, totalDiscount, totalExpenses, totalPrice, costs)
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs)
 		str := r.Report()
 
 		// Assert
@@ -96,7 +97,173 @@ func TestReport(t *testing.T) {
 		assert.Contains(t, str, "TOTAL")
 	})
 
-	t.Run("TEST_REPORT_", func(t *testing.T) {
+	// Case when the result is a credit note
+	t.Run("TEST_REPORT_CREDIT", func(t *testing.T) {
+		// Arrange
+		startingPrice := models.NewMoney(currency.USD, "20.25").Neg()
+		taxes := []models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.USD, "4.05").Neg()}}
+		totalDiscount := models.Money{}
+		totalExpenses := models.Money{}
+		totalPrice := models.NewMoney(currency.USD, "24.30").Neg()
+		var costs []models.ExpenseLine
+
+		// Act
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs).MarkCredit()
+		str := r.Report()
+
+		// Assert
+		assert.True(t, r.IsCredit())
+		assert.Contains(t, str, "CREDIT NOTE")
+		assert.Contains(t, str, "Tax = -4.05 USD")
+		assert.Contains(t, str, "TOTAL = -24.30 USD")
+	})
+
+	// Case when the result has a display currency
+	t.Run("TEST_REPORT_DISPLAY_CURRENCY", func(t *testing.T) {
+		// Arrange
+		costs := []models.ExpenseLine{{Description: "Transport", Amount: models.NewMoney(currency.EUR, "2.20")}}
+		displayCosts := []models.ExpenseLine{{Description: "Transport", Amount: models.NewMoney(currency.GBP, "1.88")}}
+
+		display := NewResult(
+			models.NewMoney(currency.GBP, "17.28"),
+			[]models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.GBP, "3.46")}},
+			models.Money{Currency: currency.GBP},
+			models.NewMoney(currency.GBP, "1.88"),
+			models.NewMoney(currency.GBP, "22.62"),
+			displayCosts,
+		)
+
+		// Act
+		r := NewResult(
+			models.NewMoney(currency.EUR, "20.25"),
+			[]models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.EUR, "4.05")}},
+			models.Money{Currency: currency.EUR},
+			models.NewMoney(currency.EUR, "2.20"),
+			models.NewMoney(currency.EUR, "26.50"),
+			costs,
+		).WithDisplay(display, 85350000)
+		str := r.Report()
+
+		// Assert
+		assert.Equal(t, display, r.Display())
+		assert.Contains(t, str, "Cost = 20.25 EUR (17.28 GBP)")
+		assert.Contains(t, str, "Tax = 4.05 EUR (3.46 GBP)")
+		assert.Contains(t, str, "Transport = 2.20 EUR (1.88 GBP)")
+		assert.Contains(t, str, "TOTAL = 26.50 EUR (22.62 GBP)")
+		assert.Contains(t, str, "Rate = 1 EUR = 0.8535 GBP")
+	})
+
+	// Case when the total is rounded for cash payment
+	t.Run("TEST_REPORT_CASH_ROUNDING", func(t *testing.T) {
+		// Arrange
+		startingPrice := models.NewMoney(currency.CHF, "20.25")
+		taxes := []models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.CHF, "4.05")}}
+		totalDiscount := models.Money{}
+		totalExpenses := models.Money{}
+		totalPrice := models.NewMoney(currency.CHF, "24.32")
+		var costs []models.ExpenseLine
+
+		// Act
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs).
+			RoundCash(models.NewCurrencyCashRounding(format.HalfUp))
+		str := r.Report()
+
+		// Assert
+		assert.Contains(t, str, "Cash rounding = -0.02 CHF")
+		assert.Contains(t, str, "TOTAL = 24.30 CHF")
+	})
+
+	// Case when the store rounds the total for cash payment and the result has a display currency with a different cash increment
+	t.Run("TEST_REPORT_CASH_ROUNDING_DISPLAY_CURRENCY", func(t *testing.T) {
+		// Arrange
+		display := NewResult(
+			models.NewMoney(currency.EUR, "19.54"),
+			[]models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.EUR, "3.91")}},
+			models.Money{Currency: currency.EUR},
+			models.Money{Currency: currency.EUR},
+			models.NewMoney(currency.EUR, "23.47"),
+			nil,
+		)
+
+		// Act
+		r := NewResult(
+			models.NewMoney(currency.CHF, "20.25"),
+			[]models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.CHF, "4.05")}},
+			models.Money{Currency: currency.CHF},
+			models.Money{Currency: currency.CHF},
+			models.NewMoney(currency.CHF, "24.32"),
+			nil,
+		).WithDisplay(display, 96500000).RoundCash(models.NewCashRounding("1", format.HalfUp))
+		str := r.Report()
+
+		// Assert
+		assert.Equal(t, models.NewMoney(currency.CHF, "24"), r.TotalPrice())
+		assert.Equal(t, models.NewMoney(currency.EUR, "23.47"), r.Display().TotalPrice())
+		assert.True(t, r.Display().CashRounding().IsZero())
+		assert.Contains(t, str, "Cash rounding = -0.32 CHF (0.00 EUR)")
+	})
+
+	// Case when there is no cash rounding adjustment
+	t.Run("TEST_REPORT_NO_CASH_ROUNDING", func(t *testing.T) {
+		// Arrange
+		startingPrice := models.NewMoney(currency.CHF, "20.25")
+		taxes := []models.TaxLine{{Description: "Tax", Amount: models.NewMoney(currency.CHF, "4.05")}}
+		totalDiscount := models.Money{}
+		totalExpenses := models.Money{}
+		totalPrice := models.NewMoney(currency.CHF, "24.30")
+		var costs []models.ExpenseLine
+
+		// Act
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs).
+			RoundCash(models.NewCurrencyCashRounding(format.HalfUp))
+		str := r.Report()
+
+		// Assert
+		assert.NotContains(t, str, "Cash rounding")
+		assert.Contains(t, str, "TOTAL = 24.30 CHF")
+	})
+
+	// Case when there are several taxes
+	t.Run("TEST_REPORT_MULTIPLE_TAXES", func(t *testing.T) {
+		// Arrange
+		startingPrice := models.NewMoney(currency.CAD, "20.00")
+		taxes := []models.TaxLine{
+			{Description: "GST", Amount: models.NewMoney(currency.CAD, "1.00")},
+			{Description: "QST", Amount: models.NewMoney(currency.CAD, "2.10")},
+		}
+		totalDiscount := models.Money{}
+		totalExpenses := models.Money{}
+		totalPrice := models.NewMoney(currency.CAD, "23.10")
+		var costs []models.ExpenseLine
+
+		expectedTax := models.NewMoney(currency.CAD, "3.10")
+
+		// Act
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs)
+		str := r.Report()
+
+		// Assert
+		assert.Equal(t, expectedTax, r.TaxAmount())
+		assert.Contains(t, str, "GST = 1.00 CAD")
+		assert.Contains(t, str, "QST = 2.10 CAD")
+	})
+	// Case when the result carries a note
+	t.Run("TEST_REPORT_NOTES", func(t *testing.T) {
+		// Arrange
+		startingPrice := models.NewMoney(currency.EUR, "20.25")
+		var taxes []models.TaxLine
+		totalDiscount := models.Money{}
+		totalExpenses := models.Money{}
+		totalPrice := models.NewMoney(currency.EUR, "20.25")
+		var costs []models.ExpenseLine
 
+		// Act
+		r := NewResult(startingPrice, taxes, totalDiscount, totalExpenses, totalPrice, costs).
+			AddNote("Reverse charge")
+		str := r.Report()
+
+		// Assert
+		assert.Equal(t, []string{"Reverse charge"}, r.Notes())
+		assert.Contains(t, str, "Note: Reverse charge")
 	})
 }